@@ -80,6 +80,10 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &${originName}{}
+			}
 			new${elementName}((*es.orig)[i]).CopyTo(new${elementName}((*dest.orig)[i]))
 		}
 		return
@@ -188,6 +192,14 @@ func Test${structName}_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTest${structName}().CopyTo(dest)
 	assert.EqualValues(t, generateTest${structName}(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*${originName}, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTest${structName}().CopyTo(dest)
+	assert.EqualValues(t, generateTest${structName}(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func Test${structName}_Resize(t *testing.T) {
@@ -423,6 +435,14 @@ func Test${structName}_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTest${structName}().CopyTo(dest)
 	assert.EqualValues(t, generateTest${structName}(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]${originName}, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTest${structName}().CopyTo(dest)
+	assert.EqualValues(t, generateTest${structName}(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func Test${structName}_Resize(t *testing.T) {
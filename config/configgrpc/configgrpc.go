@@ -148,6 +148,11 @@ type GRPCServerSettings struct {
 	// It has effect only for streaming RPCs.
 	MaxConcurrentStreams uint32 `mapstructure:"max_concurrent_streams"`
 
+	// MaxConnections sets the limit on the number of connections open at the same time.
+	// Connections over the limit are closed as soon as they are accepted.
+	// The default value 0 means no limit.
+	MaxConnections int `mapstructure:"max_connections"`
+
 	// ReadBufferSize for gRPC server. See grpc.ReadBufferSize
 	// (https://godoc.org/google.golang.org/grpc#ReadBufferSize).
 	ReadBufferSize int `mapstructure:"read_buffer_size"`
@@ -232,7 +237,20 @@ func validateBalancerName(balancerName string) bool {
 }
 
 func (gss *GRPCServerSettings) ToListener() (net.Listener, error) {
-	return gss.NetAddr.Listen()
+	ln, err := gss.NetAddr.Listen()
+	if err != nil {
+		return nil, err
+	}
+	return gss.WrapListener(ln), nil
+}
+
+// WrapListener applies the connection settings to a listener bound elsewhere,
+// e.g. when the socket is shared with other servers.
+func (gss *GRPCServerSettings) WrapListener(ln net.Listener) net.Listener {
+	if gss.MaxConnections > 0 {
+		ln = newConnLimitListener(ln, gss.MaxConnections)
+	}
+	return ln
 }
 
 // ToServerOption maps configgrpc.GRPCServerSettings to a slice of server options for gRPC
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// connLimitListener is a net.Listener that closes the accepted connections
+// that would exceed the maximum number of open connections.
+type connLimitListener struct {
+	net.Listener
+	limit int64
+	open  int64
+}
+
+func newConnLimitListener(ln net.Listener, limit int) net.Listener {
+	return &connLimitListener{Listener: ln, limit: int64(limit)}
+}
+
+// Accept waits for and returns the next connection under the limit.
+func (l *connLimitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if atomic.AddInt64(&l.open, 1) <= l.limit {
+			return &limitedConn{Conn: conn, release: l.release}, nil
+		}
+		l.release()
+		_ = conn.Close()
+	}
+}
+
+func (l *connLimitListener) release() {
+	atomic.AddInt64(&l.open, -1)
+}
+
+// limitedConn gives back its slot to the listener when closed.
+type limitedConn struct {
+	net.Conn
+	closeOnce sync.Once
+	release   func()
+}
+
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(c.release)
+	return err
+}
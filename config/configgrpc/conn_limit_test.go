@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func TestMaxConnections(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  testutil.GetAvailableLocalAddress(t),
+			Transport: "tcp",
+		},
+		MaxConnections: 1,
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	defer ln.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	first, err := net.Dial("tcp", gss.NetAddr.Endpoint)
+	require.NoError(t, err)
+	defer first.Close()
+	serverConn := <-accepted
+
+	// The second connection is over the limit and closed by the server.
+	second, err := net.Dial("tcp", gss.NetAddr.Endpoint)
+	require.NoError(t, err)
+	defer second.Close()
+	require.NoError(t, second.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = ioutil.ReadAll(second)
+	assert.NoError(t, err)
+
+	// Closing the first connection frees its slot.
+	require.NoError(t, serverConn.Close())
+	third, err := net.Dial("tcp", gss.NetAddr.Endpoint)
+	require.NoError(t, err)
+	defer third.Close()
+	select {
+	case conn := <-accepted:
+		assert.NoError(t, conn.Close())
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection under the limit was not accepted")
+	}
+}
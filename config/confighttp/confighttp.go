@@ -16,6 +16,7 @@ package confighttp
 
 import (
 	"crypto/tls"
+	"fmt"
 	"net"
 	"net/http"
 	"time"
@@ -114,6 +115,24 @@ type HTTPServerSettings struct {
 	// An empty list means that CORS is not enabled at all. A wildcard (*) can be
 	// used to match any origin or one or more characters of an origin.
 	CorsOrigins []string `mapstructure:"cors_allowed_origins"`
+
+	// ReadHeaderTimeout is the amount of time allowed to read the request headers.
+	// Zero means there is no timeout.
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+
+	// ReadTimeout is the maximum duration for reading the entire request, including the body.
+	// Zero means there is no timeout.
+	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+
+	// IdleTimeout is the maximum amount of time to wait for the next request when keep-alives
+	// are enabled. Zero means the value of ReadTimeout is used.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// MaxRequestBodySize is the maximum size in bytes of a request body, before its
+	// decompression. Requests declaring a larger Content-Length are rejected before
+	// their body is read, and reading the body of the others stops at the limit.
+	// Zero means there is no limit.
+	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
 }
 
 func (hss *HTTPServerSettings) ToListener() (net.Listener, error) {
@@ -142,7 +161,8 @@ type toServerOptions struct {
 type ToServerOption func(opts *toServerOptions)
 
 // WithErrorHandler overrides the HTTP error handler that gets invoked
-// when there is a failure inside middleware.HTTPContentDecompressor.
+// when there is a failure inside middleware.HTTPContentDecompressor or
+// when a request body is larger than MaxRequestBodySize.
 func WithErrorHandler(e middleware.ErrorHandler) ToServerOption {
 	return func(opts *toServerOptions) {
 		opts.errorHandler = e
@@ -162,7 +182,33 @@ func (hss *HTTPServerSettings) ToServer(handler http.Handler, opts ...ToServerOp
 		handler,
 		middleware.WithErrorHandler(serverOpts.errorHandler),
 	)
+	if hss.MaxRequestBodySize > 0 {
+		// Applied before the decompression to limit the bytes read from the network.
+		handler = limitRequestBody(handler, hss.MaxRequestBodySize, serverOpts.errorHandler)
+	}
 	return &http.Server{
-		Handler: handler,
+		Handler:           handler,
+		ReadHeaderTimeout: hss.ReadHeaderTimeout,
+		ReadTimeout:       hss.ReadTimeout,
+		IdleTimeout:       hss.IdleTimeout,
 	}
 }
+
+// limitRequestBody rejects the requests declaring a body larger than maxBytes with
+// 413 Request Entity Too Large before reading them. The body of the other requests,
+// e.g. chunked ones without a Content-Length, fails to be read past maxBytes.
+func limitRequestBody(h http.Handler, maxBytes int64, errorHandler middleware.ErrorHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBytes {
+			errMsg := fmt.Sprintf("request body of %d bytes is larger than the limit of %d bytes", r.ContentLength, maxBytes)
+			if errorHandler != nil {
+				errorHandler(w, r, errMsg, http.StatusRequestEntityTooLarge)
+			} else {
+				http.Error(w, errMsg, http.StatusRequestEntityTooLarge)
+			}
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		h.ServeHTTP(w, r)
+	})
+}
@@ -17,11 +17,13 @@ package confighttp
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -372,6 +374,54 @@ func verifyCorsResp(t *testing.T, url string, origin string, wantStatus int, wan
 	assert.Equal(t, wantAllowMethods, gotAllowMethods)
 }
 
+func TestHTTPServerTimeouts(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint:          "localhost:0",
+		ReadHeaderTimeout: time.Second,
+		ReadTimeout:       2 * time.Second,
+		IdleTimeout:       3 * time.Second,
+	}
+	s := hss.ToServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	assert.Equal(t, time.Second, s.ReadHeaderTimeout)
+	assert.Equal(t, 2*time.Second, s.ReadTimeout)
+	assert.Equal(t, 3*time.Second, s.IdleTimeout)
+}
+
+func TestHTTPServerMaxRequestBodySize(t *testing.T) {
+	hss := &HTTPServerSettings{MaxRequestBodySize: 10}
+	var called bool
+	s := hss.ToServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if _, err := ioutil.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	tests := []struct {
+		name          string
+		body          io.Reader
+		contentLength int64
+		status        int
+		called        bool
+	}{
+		{name: "UnderLimit", body: strings.NewReader("0123456789"), contentLength: 10, status: http.StatusOK, called: true},
+		{name: "ContentLengthOverLimit", body: strings.NewReader("0123456789a"), contentLength: 11, status: http.StatusRequestEntityTooLarge},
+		// Without a Content-Length the body is streamed and reading it fails at the limit.
+		{name: "ChunkedOverLimit", body: strings.NewReader("0123456789a"), contentLength: -1, status: http.StatusBadRequest, called: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			req := httptest.NewRequest("POST", "/", tt.body)
+			req.ContentLength = tt.contentLength
+			rec := httptest.NewRecorder()
+			s.Handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.called, called)
+		})
+	}
+}
+
 func ExampleHTTPServerSettings() {
 	settings := HTTPServerSettings{
 		Endpoint: ":443",
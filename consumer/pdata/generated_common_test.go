@@ -102,6 +102,14 @@ func TestAnyValueArray_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestAnyValueArray().CopyTo(dest)
 	assert.EqualValues(t, generateTestAnyValueArray(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]otlpcommon.AnyValue, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestAnyValueArray().CopyTo(dest)
+	assert.EqualValues(t, generateTestAnyValueArray(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestAnyValueArray_Resize(t *testing.T) {
@@ -83,6 +83,10 @@ func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlplogs.ResourceLogs{}
+			}
 			newResourceLogs((*es.orig)[i]).CopyTo(newResourceLogs((*dest.orig)[i]))
 		}
 		return
@@ -239,6 +243,10 @@ func (es InstrumentationLibraryLogsSlice) CopyTo(dest InstrumentationLibraryLogs
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlplogs.InstrumentationLibraryLogs{}
+			}
 			newInstrumentationLibraryLogs((*es.orig)[i]).CopyTo(newInstrumentationLibraryLogs((*dest.orig)[i]))
 		}
 		return
@@ -395,6 +403,10 @@ func (es LogSlice) CopyTo(dest LogSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlplogs.LogRecord{}
+			}
 			newLogRecord((*es.orig)[i]).CopyTo(newLogRecord((*dest.orig)[i]))
 		}
 		return
@@ -80,6 +80,14 @@ func TestResourceLogsSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestResourceLogsSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestResourceLogsSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlplogs.ResourceLogs, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestResourceLogsSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestResourceLogsSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestResourceLogsSlice_Resize(t *testing.T) {
@@ -213,6 +221,14 @@ func TestInstrumentationLibraryLogsSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestInstrumentationLibraryLogsSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestInstrumentationLibraryLogsSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlplogs.InstrumentationLibraryLogs, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestInstrumentationLibraryLogsSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestInstrumentationLibraryLogsSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestInstrumentationLibraryLogsSlice_Resize(t *testing.T) {
@@ -346,6 +362,14 @@ func TestLogSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestLogSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestLogSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlplogs.LogRecord, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestLogSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestLogSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestLogSlice_Resize(t *testing.T) {
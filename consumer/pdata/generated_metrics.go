@@ -82,6 +82,10 @@ func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.ResourceMetrics{}
+			}
 			newResourceMetrics((*es.orig)[i]).CopyTo(newResourceMetrics((*dest.orig)[i]))
 		}
 		return
@@ -238,6 +242,10 @@ func (es InstrumentationLibraryMetricsSlice) CopyTo(dest InstrumentationLibraryM
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.InstrumentationLibraryMetrics{}
+			}
 			newInstrumentationLibraryMetrics((*es.orig)[i]).CopyTo(newInstrumentationLibraryMetrics((*dest.orig)[i]))
 		}
 		return
@@ -394,6 +402,10 @@ func (es MetricSlice) CopyTo(dest MetricSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.Metric{}
+			}
 			newMetric((*es.orig)[i]).CopyTo(newMetric((*dest.orig)[i]))
 		}
 		return
@@ -512,6 +524,8 @@ func (ms Metric) SetUnit(v string) {
 	(*ms.orig).Unit = v
 }
 
+
+
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Metric) CopyTo(dest Metric) {
 	dest.SetName(ms.Name())
@@ -909,6 +923,10 @@ func (es IntDataPointSlice) CopyTo(dest IntDataPointSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.IntDataPoint{}
+			}
 			newIntDataPoint((*es.orig)[i]).CopyTo(newIntDataPoint((*dest.orig)[i]))
 		}
 		return
@@ -1110,6 +1128,10 @@ func (es DoubleDataPointSlice) CopyTo(dest DoubleDataPointSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.DoubleDataPoint{}
+			}
 			newDoubleDataPoint((*es.orig)[i]).CopyTo(newDoubleDataPoint((*dest.orig)[i]))
 		}
 		return
@@ -1311,6 +1333,10 @@ func (es IntHistogramDataPointSlice) CopyTo(dest IntHistogramDataPointSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.IntHistogramDataPoint{}
+			}
 			newIntHistogramDataPoint((*es.orig)[i]).CopyTo(newIntHistogramDataPoint((*dest.orig)[i]))
 		}
 		return
@@ -1557,6 +1583,10 @@ func (es DoubleHistogramDataPointSlice) CopyTo(dest DoubleHistogramDataPointSlic
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.DoubleHistogramDataPoint{}
+			}
 			newDoubleHistogramDataPoint((*es.orig)[i]).CopyTo(newDoubleHistogramDataPoint((*dest.orig)[i]))
 		}
 		return
@@ -1803,6 +1833,10 @@ func (es DoubleSummaryDataPointSlice) CopyTo(dest DoubleSummaryDataPointSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.DoubleSummaryDataPoint{}
+			}
 			newDoubleSummaryDataPoint((*es.orig)[i]).CopyTo(newDoubleSummaryDataPoint((*dest.orig)[i]))
 		}
 		return
@@ -2019,6 +2053,10 @@ func (es ValueAtQuantileSlice) CopyTo(dest ValueAtQuantileSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.DoubleSummaryDataPoint_ValueAtQuantile{}
+			}
 			newValueAtQuantile((*es.orig)[i]).CopyTo(newValueAtQuantile((*dest.orig)[i]))
 		}
 		return
@@ -2189,6 +2227,10 @@ func (es IntExemplarSlice) CopyTo(dest IntExemplarSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.IntExemplar{}
+			}
 			newIntExemplar((*es.orig)[i]).CopyTo(newIntExemplar((*dest.orig)[i]))
 		}
 		return
@@ -2370,6 +2412,10 @@ func (es DoubleExemplarSlice) CopyTo(dest DoubleExemplarSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlpmetrics.DoubleExemplar{}
+			}
 			newDoubleExemplar((*es.orig)[i]).CopyTo(newDoubleExemplar((*dest.orig)[i]))
 		}
 		return
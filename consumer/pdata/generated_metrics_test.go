@@ -80,6 +80,14 @@ func TestResourceMetricsSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestResourceMetricsSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestResourceMetricsSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.ResourceMetrics, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestResourceMetricsSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestResourceMetricsSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestResourceMetricsSlice_Resize(t *testing.T) {
@@ -213,6 +221,14 @@ func TestInstrumentationLibraryMetricsSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestInstrumentationLibraryMetricsSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestInstrumentationLibraryMetricsSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.InstrumentationLibraryMetrics, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestInstrumentationLibraryMetricsSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestInstrumentationLibraryMetricsSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestInstrumentationLibraryMetricsSlice_Resize(t *testing.T) {
@@ -346,6 +362,14 @@ func TestMetricSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestMetricSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestMetricSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.Metric, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestMetricSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestMetricSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestMetricSlice_Resize(t *testing.T) {
@@ -635,6 +659,14 @@ func TestIntDataPointSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestIntDataPointSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestIntDataPointSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.IntDataPoint, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestIntDataPointSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestIntDataPointSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestIntDataPointSlice_Resize(t *testing.T) {
@@ -794,6 +826,14 @@ func TestDoubleDataPointSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestDoubleDataPointSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestDoubleDataPointSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.DoubleDataPoint, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestDoubleDataPointSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestDoubleDataPointSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestDoubleDataPointSlice_Resize(t *testing.T) {
@@ -953,6 +993,14 @@ func TestIntHistogramDataPointSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestIntHistogramDataPointSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestIntHistogramDataPointSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.IntHistogramDataPoint, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestIntHistogramDataPointSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestIntHistogramDataPointSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestIntHistogramDataPointSlice_Resize(t *testing.T) {
@@ -1136,6 +1184,14 @@ func TestDoubleHistogramDataPointSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestDoubleHistogramDataPointSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestDoubleHistogramDataPointSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.DoubleHistogramDataPoint, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestDoubleHistogramDataPointSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestDoubleHistogramDataPointSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestDoubleHistogramDataPointSlice_Resize(t *testing.T) {
@@ -1319,6 +1375,14 @@ func TestDoubleSummaryDataPointSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestDoubleSummaryDataPointSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestDoubleSummaryDataPointSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.DoubleSummaryDataPoint, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestDoubleSummaryDataPointSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestDoubleSummaryDataPointSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestDoubleSummaryDataPointSlice_Resize(t *testing.T) {
@@ -1486,6 +1550,14 @@ func TestValueAtQuantileSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestValueAtQuantileSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestValueAtQuantileSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.DoubleSummaryDataPoint_ValueAtQuantile, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestValueAtQuantileSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestValueAtQuantileSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestValueAtQuantileSlice_Resize(t *testing.T) {
@@ -1621,6 +1693,14 @@ func TestIntExemplarSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestIntExemplarSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestIntExemplarSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.IntExemplar, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestIntExemplarSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestIntExemplarSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestIntExemplarSlice_Resize(t *testing.T) {
@@ -1764,6 +1844,14 @@ func TestDoubleExemplarSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestDoubleExemplarSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestDoubleExemplarSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlpmetrics.DoubleExemplar, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestDoubleExemplarSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestDoubleExemplarSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestDoubleExemplarSlice_Resize(t *testing.T) {
@@ -83,6 +83,10 @@ func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlptrace.ResourceSpans{}
+			}
 			newResourceSpans((*es.orig)[i]).CopyTo(newResourceSpans((*dest.orig)[i]))
 		}
 		return
@@ -239,6 +243,10 @@ func (es InstrumentationLibrarySpansSlice) CopyTo(dest InstrumentationLibrarySpa
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlptrace.InstrumentationLibrarySpans{}
+			}
 			newInstrumentationLibrarySpans((*es.orig)[i]).CopyTo(newInstrumentationLibrarySpans((*dest.orig)[i]))
 		}
 		return
@@ -395,6 +403,10 @@ func (es SpanSlice) CopyTo(dest SpanSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlptrace.Span{}
+			}
 			newSpan((*es.orig)[i]).CopyTo(newSpan((*dest.orig)[i]))
 		}
 		return
@@ -733,6 +745,10 @@ func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlptrace.Span_Event{}
+			}
 			newSpanEvent((*es.orig)[i]).CopyTo(newSpanEvent((*dest.orig)[i]))
 		}
 		return
@@ -927,6 +943,10 @@ func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
+			// The capacity grown by append is not backed by elements.
+			if (*dest.orig)[i] == nil {
+				(*dest.orig)[i] = &otlptrace.Span_Link{}
+			}
 			newSpanLink((*es.orig)[i]).CopyTo(newSpanLink((*dest.orig)[i]))
 		}
 		return
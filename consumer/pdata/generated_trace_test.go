@@ -80,6 +80,14 @@ func TestResourceSpansSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestResourceSpansSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestResourceSpansSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlptrace.ResourceSpans, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestResourceSpansSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestResourceSpansSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestResourceSpansSlice_Resize(t *testing.T) {
@@ -213,6 +221,14 @@ func TestInstrumentationLibrarySpansSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestInstrumentationLibrarySpansSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestInstrumentationLibrarySpansSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlptrace.InstrumentationLibrarySpans, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestInstrumentationLibrarySpansSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestInstrumentationLibrarySpansSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestInstrumentationLibrarySpansSlice_Resize(t *testing.T) {
@@ -346,6 +362,14 @@ func TestSpanSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestSpanSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestSpanSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlptrace.Span, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestSpanSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestSpanSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestSpanSlice_Resize(t *testing.T) {
@@ -583,6 +607,14 @@ func TestSpanEventSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestSpanEventSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestSpanEventSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlptrace.Span_Event, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestSpanEventSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestSpanEventSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestSpanEventSlice_Resize(t *testing.T) {
@@ -734,6 +766,14 @@ func TestSpanLinkSlice_CopyTo(t *testing.T) {
 	// Test CopyTo same size slice
 	generateTestSpanLinkSlice().CopyTo(dest)
 	assert.EqualValues(t, generateTestSpanLinkSlice(), dest)
+
+	// Test CopyTo reuses the capacity of the destination
+	*dest.orig = make([]*otlptrace.Span_Link, 0, 8)
+	backing := &(*dest.orig)[:1][0]
+	generateTestSpanLinkSlice().CopyTo(dest)
+	assert.EqualValues(t, generateTestSpanLinkSlice(), dest)
+	assert.Equal(t, 8, cap(*dest.orig))
+	assert.True(t, backing == &(*dest.orig)[0])
 }
 
 func TestSpanLinkSlice_Resize(t *testing.T) {
@@ -15,6 +15,8 @@
 package pdata
 
 import (
+	"io"
+
 	"go.opentelemetry.io/collector/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
 	otlplogs "go.opentelemetry.io/collector/internal/data/protogen/logs/v1"
@@ -61,6 +63,26 @@ func (ld Logs) ToOtlpProtoBytes() ([]byte, error) {
 	return logs.Marshal()
 }
 
+// WriteOtlpProtoBytes writes the same bytes as ToOtlpProtoBytes to w. The
+// ResourceLogs are marshaled one at a time, so only the largest of them is held
+// in memory instead of the whole request.
+func (ld Logs) WriteOtlpProtoBytes(w io.Writer) error {
+	// A request with a single element marshals to the bytes of that element in the
+	// full request, the concatenation of which is the full request.
+	single := otlpcollectorlog.ExportLogsServiceRequest{
+		ResourceLogs: make([]*otlplogs.ResourceLogs, 1),
+	}
+	var buf []byte
+	for _, r := range *ld.orig {
+		single.ResourceLogs[0] = r
+		var err error
+		if buf, err = writeProto(w, buf, &single); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // FromOtlpProtoBytes converts OTLP Collector ExportLogsServiceRequest
 // ProtoBuf bytes to the internal Logs. Overrides current data.
 // Calling this function on zero-initialized structure causes panic.
@@ -15,6 +15,7 @@
 package pdata
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,6 +90,20 @@ func TestLogsFromInvalidOtlpProtoBytes(t *testing.T) {
 	assert.EqualError(t, err, "unexpected EOF")
 }
 
+func TestLogsWriteOtlpProtoBytes(t *testing.T) {
+	td := NewLogs()
+	var empty bytes.Buffer
+	require.NoError(t, td.WriteOtlpProtoBytes(&empty))
+	assert.Zero(t, empty.Len())
+
+	fillTestResourceLogsSlice(td.ResourceLogs())
+	want, err := td.ToOtlpProtoBytes()
+	require.NoError(t, err)
+	var got bytes.Buffer
+	require.NoError(t, td.WriteOtlpProtoBytes(&got))
+	assert.Equal(t, want, got.Bytes())
+}
+
 func TestLogsClone(t *testing.T) {
 	logs := NewLogs()
 	fillTestResourceLogsSlice(logs.ResourceLogs())
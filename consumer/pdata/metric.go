@@ -15,6 +15,10 @@
 package pdata
 
 import (
+	"io"
+	"math"
+	"sort"
+
 	otlpcollectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
 	otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
 )
@@ -79,6 +83,26 @@ func (md Metrics) FromOtlpProtoBytes(data []byte) error {
 	return nil
 }
 
+// WriteOtlpProtoBytes writes the same bytes as ToOtlpProtoBytes to w. The
+// ResourceMetrics are marshaled one at a time, so only the largest of them is held
+// in memory instead of the whole request.
+func (md Metrics) WriteOtlpProtoBytes(w io.Writer) error {
+	// A request with a single element marshals to the bytes of that element in the
+	// full request, the concatenation of which is the full request.
+	single := otlpcollectormetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: make([]*otlpmetrics.ResourceMetrics, 1),
+	}
+	var buf []byte
+	for _, r := range *md.orig {
+		single.ResourceMetrics[0] = r
+		var err error
+		if buf, err = writeProto(w, buf, &single); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Clone returns a copy of MetricData.
 func (md Metrics) Clone() Metrics {
 	rms := NewResourceMetricsSlice()
@@ -280,6 +304,88 @@ func (ms Metric) DoubleSummary() DoubleSummary {
 	return newDoubleSummary(ms.orig.Data.(*otlpmetrics.Metric_DoubleSummary).DoubleSummary)
 }
 
+// RemoveAll removes all the data points of the DoubleSummary. The backing slice
+// keeps its capacity, so that a pooled DoubleSummary can be reused without
+// reallocating it.
+func (ms DoubleSummary) RemoveAll() {
+	dps := ms.orig.DataPoints
+	for i := range dps {
+		// Release the removed data points.
+		dps[i] = nil
+	}
+	ms.orig.DataPoints = dps[:0]
+}
+
+// Equal returns whether the DoubleSummary has the same data points as other, in
+// the same order. Two data points are equal if they have the same labels,
+// timestamps, count, sum and quantile values, the quantile values being compared
+// regardless of their order. NaN values are equal to each other.
+func (ms DoubleSummary) Equal(other DoubleSummary) bool {
+	dps, otherDps := ms.orig.DataPoints, other.orig.DataPoints
+	if len(dps) != len(otherDps) {
+		return false
+	}
+	for i := range dps {
+		if !doubleSummaryDataPointsEqual(dps[i], otherDps[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func doubleSummaryDataPointsEqual(dp, other *otlpmetrics.DoubleSummaryDataPoint) bool {
+	if dp.StartTimeUnixNano != other.StartTimeUnixNano ||
+		dp.TimeUnixNano != other.TimeUnixNano ||
+		dp.Count != other.Count ||
+		!floatsEqual(dp.Sum, other.Sum) ||
+		len(dp.QuantileValues) != len(other.QuantileValues) {
+		return false
+	}
+	if !stringMapsEqual(newStringMap(&dp.Labels), newStringMap(&other.Labels)) {
+		return false
+	}
+	quantiles, otherQuantiles := sortedQuantiles(dp.QuantileValues), sortedQuantiles(other.QuantileValues)
+	for i := range quantiles {
+		if !floatsEqual(quantiles[i].Quantile, otherQuantiles[i].Quantile) ||
+			!floatsEqual(quantiles[i].Value, otherQuantiles[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedQuantiles returns a copy of the quantile values sorted by quantile and value.
+func sortedQuantiles(orig []*otlpmetrics.DoubleSummaryDataPoint_ValueAtQuantile) []otlpmetrics.DoubleSummaryDataPoint_ValueAtQuantile {
+	quantiles := make([]otlpmetrics.DoubleSummaryDataPoint_ValueAtQuantile, len(orig))
+	for i, q := range orig {
+		quantiles[i] = *q
+	}
+	sort.Slice(quantiles, func(i, j int) bool {
+		if quantiles[i].Quantile != quantiles[j].Quantile {
+			return quantiles[i].Quantile < quantiles[j].Quantile
+		}
+		return quantiles[i].Value < quantiles[j].Value
+	})
+	return quantiles
+}
+
+func stringMapsEqual(sm, other StringMap) bool {
+	if sm.Len() != other.Len() {
+		return false
+	}
+	equal := true
+	sm.ForEach(func(k string, v string) {
+		if otherV, ok := other.Get(k); !ok || otherV != v {
+			equal = false
+		}
+	})
+	return equal
+}
+
+func floatsEqual(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
 func copyData(src, dest *otlpmetrics.Metric) {
 	switch srcData := (src).Data.(type) {
 	case *otlpmetrics.Metric_IntGauge:
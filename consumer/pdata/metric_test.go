@@ -15,6 +15,8 @@
 package pdata
 
 import (
+	"bytes"
+	"math"
 	"testing"
 
 	gogoproto "github.com/gogo/protobuf/proto"
@@ -712,6 +714,20 @@ func TestMetricsFromInvalidOtlpProtoBytes(t *testing.T) {
 	assert.EqualError(t, err, "unexpected EOF")
 }
 
+func TestMetricsWriteOtlpProtoBytes(t *testing.T) {
+	td := NewMetrics()
+	var empty bytes.Buffer
+	require.NoError(t, td.WriteOtlpProtoBytes(&empty))
+	assert.Zero(t, empty.Len())
+
+	fillTestResourceMetricsSlice(td.ResourceMetrics())
+	want, err := td.ToOtlpProtoBytes()
+	require.NoError(t, err)
+	var got bytes.Buffer
+	require.NoError(t, td.WriteOtlpProtoBytes(&got))
+	assert.Equal(t, want, got.Bytes())
+}
+
 func TestMetricsClone(t *testing.T) {
 	metrics := NewMetrics()
 	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
@@ -730,6 +746,100 @@ func BenchmarkMetricsClone(b *testing.B) {
 	}
 }
 
+func TestDoubleSummaryRemoveAll(t *testing.T) {
+	ds := NewDoubleSummary()
+	ds.RemoveAll()
+	assert.Equal(t, 0, ds.DataPoints().Len())
+
+	generateTestDoubleSummaryDataPointSlice().CopyTo(ds.DataPoints())
+	capacity := cap(ds.orig.DataPoints)
+	require.NotZero(t, capacity)
+	ds.RemoveAll()
+	assert.Equal(t, 0, ds.DataPoints().Len())
+	assert.Equal(t, capacity, cap(ds.orig.DataPoints))
+	assert.Nil(t, ds.orig.DataPoints[:1][0])
+
+	// The instance is reusable without reallocating the data points slice.
+	backing := &ds.orig.DataPoints[:1][0]
+	generateTestDoubleSummaryDataPointSlice().CopyTo(ds.DataPoints())
+	assert.EqualValues(t, generateTestDoubleSummaryDataPointSlice(), ds.DataPoints())
+	assert.True(t, backing == &ds.orig.DataPoints[0])
+
+	ds.RemoveAll()
+	ds.DataPoints().Resize(2)
+	assert.Equal(t, 2, ds.DataPoints().Len())
+	assert.EqualValues(t, NewDoubleSummaryDataPoint(), ds.DataPoints().At(0))
+	assert.True(t, backing == &ds.orig.DataPoints[0])
+}
+
+func TestDoubleSummaryEqual(t *testing.T) {
+	newSummary := func() DoubleSummary {
+		ds := NewDoubleSummary()
+		generateTestDoubleSummaryDataPointSlice().CopyTo(ds.DataPoints())
+		dp := ds.DataPoints().At(0)
+		dp.LabelsMap().InitFromMap(map[string]string{"k1": "v1", "k2": "v2"})
+		dp.SetSum(math.NaN())
+		dp.QuantileValues().Resize(2)
+		dp.QuantileValues().At(0).SetQuantile(0.5)
+		dp.QuantileValues().At(0).SetValue(10)
+		dp.QuantileValues().At(1).SetQuantile(0.99)
+		dp.QuantileValues().At(1).SetValue(20)
+		return ds
+	}
+	assert.True(t, NewDoubleSummary().Equal(NewDoubleSummary()))
+	assert.True(t, newSummary().Equal(newSummary()))
+
+	// Labels and quantile values in a different order.
+	reordered := newSummary()
+	dp := reordered.DataPoints().At(0)
+	dp.LabelsMap().InitFromMap(map[string]string{"k2": "v2", "k1": "v1"})
+	dp.QuantileValues().At(0).SetQuantile(0.99)
+	dp.QuantileValues().At(0).SetValue(20)
+	dp.QuantileValues().At(1).SetQuantile(0.5)
+	dp.QuantileValues().At(1).SetValue(10)
+	assert.True(t, newSummary().Equal(reordered))
+
+	tests := []struct {
+		name   string
+		modify func(dp DoubleSummaryDataPoint)
+	}{
+		{name: "QuantileValue", modify: func(dp DoubleSummaryDataPoint) { dp.QuantileValues().At(1).SetValue(21) }},
+		{name: "Quantile", modify: func(dp DoubleSummaryDataPoint) { dp.QuantileValues().At(1).SetQuantile(0.9) }},
+		{name: "QuantileCount", modify: func(dp DoubleSummaryDataPoint) { dp.QuantileValues().Resize(1) }},
+		{name: "Label", modify: func(dp DoubleSummaryDataPoint) { dp.LabelsMap().Upsert("k1", "other") }},
+		{name: "LabelCount", modify: func(dp DoubleSummaryDataPoint) { dp.LabelsMap().Delete("k1") }},
+		{name: "StartTime", modify: func(dp DoubleSummaryDataPoint) { dp.SetStartTime(dp.StartTime() + 1) }},
+		{name: "Timestamp", modify: func(dp DoubleSummaryDataPoint) { dp.SetTimestamp(dp.Timestamp() + 1) }},
+		{name: "Count", modify: func(dp DoubleSummaryDataPoint) { dp.SetCount(dp.Count() + 1) }},
+		{name: "Sum", modify: func(dp DoubleSummaryDataPoint) { dp.SetSum(1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := newSummary()
+			tt.modify(other.DataPoints().At(0))
+			assert.False(t, newSummary().Equal(other))
+			assert.False(t, other.Equal(newSummary()))
+		})
+	}
+
+	fewer := newSummary()
+	fewer.DataPoints().Resize(1)
+	assert.False(t, newSummary().Equal(fewer))
+}
+
+func BenchmarkDoubleSummaryDataPointSlice_CopyTo(b *testing.B) {
+	src := generateTestDoubleSummaryDataPointSlice()
+	dest := NewDoubleSummaryDataPointSlice()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		src.CopyTo(dest)
+		if dest.Len() != src.Len() {
+			b.Fail()
+		}
+	}
+}
+
 func BenchmarkOtlpToFromInternal_PassThrough(b *testing.B) {
 	resourceMetricsList := []*otlpmetrics.ResourceMetrics{
 		{
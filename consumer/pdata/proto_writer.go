@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"io"
+)
+
+// protoMarshaler is implemented by the generated OTLP protobuf messages.
+type protoMarshaler interface {
+	Size() int
+	MarshalToSizedBuffer(dAtA []byte) (int, error)
+}
+
+// writeProto marshals msg into buf, growing it if needed, and writes the bytes to w.
+// It returns the buffer so that it can be reused for the next message.
+func writeProto(w io.Writer, buf []byte, msg protoMarshaler) ([]byte, error) {
+	size := msg.Size()
+	if cap(buf) < size {
+		buf = make([]byte, size)
+	}
+	n, err := msg.MarshalToSizedBuffer(buf[:size])
+	if err != nil {
+		return buf, err
+	}
+	_, err = w.Write(buf[:n])
+	return buf, err
+}
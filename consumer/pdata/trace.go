@@ -15,6 +15,8 @@
 package pdata
 
 import (
+	"io"
+
 	otlpcollectortrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
 	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"
 )
@@ -66,6 +68,26 @@ func (td Traces) FromOtlpProtoBytes(data []byte) error {
 	return nil
 }
 
+// WriteOtlpProtoBytes writes the same bytes as ToOtlpProtoBytes to w. The
+// ResourceSpans are marshaled one at a time, so only the largest of them is held
+// in memory instead of the whole request.
+func (td Traces) WriteOtlpProtoBytes(w io.Writer) error {
+	// A request with a single element marshals to the bytes of that element in the
+	// full request, the concatenation of which is the full request.
+	single := otlpcollectortrace.ExportTraceServiceRequest{
+		ResourceSpans: make([]*otlptrace.ResourceSpans, 1),
+	}
+	var buf []byte
+	for _, r := range *td.orig {
+		single.ResourceSpans[0] = r
+		var err error
+		if buf, err = writeProto(w, buf, &single); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Clone returns a copy of Traces.
 func (td Traces) Clone() Traces {
 	rss := NewResourceSpansSlice()
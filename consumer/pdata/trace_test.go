@@ -15,6 +15,7 @@
 package pdata
 
 import (
+	"bytes"
 	"testing"
 
 	gogoproto "github.com/gogo/protobuf/proto"
@@ -207,6 +208,20 @@ func TestTracesFromInvalidOtlpProtoBytes(t *testing.T) {
 	assert.EqualError(t, err, "unexpected EOF")
 }
 
+func TestTracesWriteOtlpProtoBytes(t *testing.T) {
+	td := NewTraces()
+	var empty bytes.Buffer
+	require.NoError(t, td.WriteOtlpProtoBytes(&empty))
+	assert.Zero(t, empty.Len())
+
+	fillTestResourceSpansSlice(td.ResourceSpans())
+	want, err := td.ToOtlpProtoBytes()
+	require.NoError(t, err)
+	var got bytes.Buffer
+	require.NoError(t, td.WriteOtlpProtoBytes(&got))
+	assert.Equal(t, want, got.Bytes())
+}
+
 func TestTracesClone(t *testing.T) {
 	traces := NewTraces()
 	fillTestResourceSpansSlice(traces.ResourceSpans())
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+// WalkAttributes calls f for each attribute of the resources, spans, span
+// events and span links. The attributes of a resource are visited before those
+// of its spans, and the attributes of a span before those of its events and
+// links.
+func (td Traces) WalkAttributes(f func(k string, v AttributeValue)) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		rs.Resource().Attributes().ForEach(f)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				span.Attributes().ForEach(f)
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					events.At(l).Attributes().ForEach(f)
+				}
+				links := span.Links()
+				for l := 0; l < links.Len(); l++ {
+					links.At(l).Attributes().ForEach(f)
+				}
+			}
+		}
+	}
+}
+
+// WalkAttributes calls f for each attribute of the resources and each label of
+// the data points, in this order for every resource. Labels are passed as
+// string values.
+func (md Metrics) WalkAttributes(f func(k string, v AttributeValue)) {
+	labelFunc := func(k string, v string) {
+		f(k, NewAttributeValueString(v))
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		rm.Resource().Attributes().ForEach(f)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ms := ilms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				switch m.DataType() {
+				case MetricDataTypeIntGauge:
+					dps := m.IntGauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).LabelsMap().ForEach(labelFunc)
+					}
+				case MetricDataTypeDoubleGauge:
+					dps := m.DoubleGauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).LabelsMap().ForEach(labelFunc)
+					}
+				case MetricDataTypeIntSum:
+					dps := m.IntSum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).LabelsMap().ForEach(labelFunc)
+					}
+				case MetricDataTypeDoubleSum:
+					dps := m.DoubleSum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).LabelsMap().ForEach(labelFunc)
+					}
+				case MetricDataTypeIntHistogram:
+					dps := m.IntHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).LabelsMap().ForEach(labelFunc)
+					}
+				case MetricDataTypeDoubleHistogram:
+					dps := m.DoubleHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).LabelsMap().ForEach(labelFunc)
+					}
+				case MetricDataTypeDoubleSummary:
+					dps := m.DoubleSummary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).LabelsMap().ForEach(labelFunc)
+					}
+				}
+			}
+		}
+	}
+}
+
+// WalkAttributes calls f for each attribute of the resources and log records,
+// in this order for every resource.
+func (ld Logs) WalkAttributes(f func(k string, v AttributeValue)) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		rl.Resource().Attributes().ForEach(f)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				logs.At(k).Attributes().ForEach(f)
+			}
+		}
+	}
+}
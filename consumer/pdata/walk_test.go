@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type visitedAttribute struct {
+	key   string
+	value AttributeValue
+}
+
+func collectAttributes(walk func(f func(k string, v AttributeValue))) []visitedAttribute {
+	var got []visitedAttribute
+	walk(func(k string, v AttributeValue) {
+		got = append(got, visitedAttribute{key: k, value: v})
+	})
+	return got
+}
+
+func TestTracesWalkAttributes(t *testing.T) {
+	td := NewTraces()
+	td.ResourceSpans().Resize(2)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().Attributes().InsertString("service.name", "svc")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(2)
+	spans.At(0).Attributes().InsertInt("span.int", 1)
+	spans.At(0).Attributes().InsertString("span.string", "a")
+	spans.At(0).Events().Resize(1)
+	spans.At(0).Events().At(0).Attributes().InsertBool("event.bool", true)
+	spans.At(0).Links().Resize(1)
+	spans.At(0).Links().At(0).Attributes().InsertDouble("link.double", 1.5)
+	spans.At(1).Attributes().InsertInt("span.int", 2)
+	td.ResourceSpans().At(1).Resource().Attributes().InsertString("service.name", "other")
+
+	assert.Equal(t, []visitedAttribute{
+		{"service.name", NewAttributeValueString("svc")},
+		{"span.int", NewAttributeValueInt(1)},
+		{"span.string", NewAttributeValueString("a")},
+		{"event.bool", NewAttributeValueBool(true)},
+		{"link.double", NewAttributeValueDouble(1.5)},
+		{"span.int", NewAttributeValueInt(2)},
+		{"service.name", NewAttributeValueString("other")},
+	}, collectAttributes(td.WalkAttributes))
+}
+
+func TestMetricsWalkAttributes(t *testing.T) {
+	md := NewMetrics()
+	md.ResourceMetrics().Resize(2)
+	rm := md.ResourceMetrics().At(0)
+	rm.Resource().Attributes().InsertString("service.name", "svc")
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ms := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	ms.Resize(4)
+	ms.At(0).SetDataType(MetricDataTypeIntSum)
+	ms.At(0).IntSum().DataPoints().Resize(2)
+	ms.At(0).IntSum().DataPoints().At(0).LabelsMap().Insert("label", "a")
+	ms.At(0).IntSum().DataPoints().At(1).LabelsMap().Insert("label", "b")
+	ms.At(1).SetDataType(MetricDataTypeDoubleGauge)
+	ms.At(1).DoubleGauge().DataPoints().Resize(1)
+	ms.At(1).DoubleGauge().DataPoints().At(0).LabelsMap().Insert("double.gauge", "c")
+	ms.At(2).SetDataType(MetricDataTypeDoubleHistogram)
+	ms.At(2).DoubleHistogram().DataPoints().Resize(1)
+	ms.At(2).DoubleHistogram().DataPoints().At(0).LabelsMap().Insert("double.histogram", "d")
+	ms.At(3).SetDataType(MetricDataTypeDoubleSummary)
+	ms.At(3).DoubleSummary().DataPoints().Resize(1)
+	ms.At(3).DoubleSummary().DataPoints().At(0).LabelsMap().Insert("double.summary", "e")
+	md.ResourceMetrics().At(1).Resource().Attributes().InsertString("service.name", "other")
+
+	assert.Equal(t, []visitedAttribute{
+		{"service.name", NewAttributeValueString("svc")},
+		{"label", NewAttributeValueString("a")},
+		{"label", NewAttributeValueString("b")},
+		{"double.gauge", NewAttributeValueString("c")},
+		{"double.histogram", NewAttributeValueString("d")},
+		{"double.summary", NewAttributeValueString("e")},
+		{"service.name", NewAttributeValueString("other")},
+	}, collectAttributes(md.WalkAttributes))
+}
+
+func TestLogsWalkAttributes(t *testing.T) {
+	ld := NewLogs()
+	ld.ResourceLogs().Resize(2)
+	rl := ld.ResourceLogs().At(0)
+	rl.Resource().Attributes().InsertString("service.name", "svc")
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(2)
+	logs.At(0).Attributes().InsertString("log.string", "a")
+	logs.At(1).Attributes().InsertInt("log.int", 2)
+	logs.At(1).Attributes().InsertString("log.string", "b")
+	ld.ResourceLogs().At(1).Resource().Attributes().InsertString("service.name", "other")
+
+	assert.Equal(t, []visitedAttribute{
+		{"service.name", NewAttributeValueString("svc")},
+		{"log.string", NewAttributeValueString("a")},
+		{"log.int", NewAttributeValueInt(2)},
+		{"log.string", NewAttributeValueString("b")},
+		{"service.name", NewAttributeValueString("other")},
+	}, collectAttributes(ld.WalkAttributes))
+}
+
+func TestWalkAttributesEmpty(t *testing.T) {
+	assert.Empty(t, collectAttributes(NewTraces().WalkAttributes))
+	assert.Empty(t, collectAttributes(NewMetrics().WalkAttributes))
+	assert.Empty(t, collectAttributes(NewLogs().WalkAttributes))
+}
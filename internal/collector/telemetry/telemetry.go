@@ -32,6 +32,8 @@ var (
 	metricsPrefixPtr *string
 
 	addInstanceIDPtr *bool
+
+	legacyBatchMetricsPtr *bool
 )
 
 func Flags(flags *flag.FlagSet) {
@@ -51,6 +53,11 @@ func Flags(flags *flag.FlagSet) {
 		"add-instance-id",
 		true,
 		"Flag to control the addition of 'service.instance.id' to the collector metrics.")
+
+	legacyBatchMetricsPtr = flags.Bool(
+		"legacy-batch-metrics",
+		false,
+		"Flag to also publish the batch processor metrics under their legacy names, without the 'processor/batch/' prefix.")
 }
 
 // GetMetricsAddrDefault returns the default metrics bind address and port depending on
@@ -67,6 +74,10 @@ func GetAddInstanceID() bool {
 	return *addInstanceIDPtr
 }
 
+func GetLegacyBatchMetrics() bool {
+	return *legacyBatchMetricsPtr
+}
+
 func GetMetricsAddr() string {
 	return *metricsAddrPtr
 }
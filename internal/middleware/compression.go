@@ -18,7 +18,11 @@ import (
 	"compress/gzip"
 	"compress/zlib"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 type ErrorHandler func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)
@@ -38,7 +42,8 @@ func WithErrorHandler(e ErrorHandler) DecompressorOption {
 // HTTPContentDecompressor is a middleware that offloads the task of handling compressed
 // HTTP requests by identifying the compression format in the "Content-Encoding" header and re-writing
 // request body so that the handlers further in the chain can work on decompressed data.
-// It supports gzip and deflate/zlib compression.
+// It supports gzip and deflate/zlib compression. The gzip readers are pooled and
+// reused across requests, whichever handler they are sent to.
 func HTTPContentDecompressor(h http.Handler, opts ...DecompressorOption) http.Handler {
 	d := &decompressor{}
 	for _, o := range opts {
@@ -74,11 +79,7 @@ func (d *decompressor) wrap(h http.Handler) http.Handler {
 func newBodyReader(r *http.Request) (io.ReadCloser, error) {
 	switch r.Header.Get("Content-Encoding") {
 	case "gzip":
-		gr, err := gzip.NewReader(r.Body)
-		if err != nil {
-			return nil, err
-		}
-		return gr, nil
+		return newGzipReader(r.Body)
 	case "deflate", "zlib":
 		zr, err := zlib.NewReader(r.Body)
 		if err != nil {
@@ -89,6 +90,159 @@ func newBodyReader(r *http.Request) (io.ReadCloser, error) {
 	return nil, nil
 }
 
+// gzipReaderPool holds the gzip readers released by the requests already
+// decompressed, so that their buffers are reused by the next ones.
+var gzipReaderPool sync.Pool
+
+// pooledGzipReader returns its gzip.Reader to gzipReaderPool when closed. Reads
+// after Close fail with io.ErrClosedPipe.
+type pooledGzipReader struct {
+	gr *gzip.Reader
+}
+
+func newGzipReader(body io.Reader) (io.ReadCloser, error) {
+	if gr, ok := gzipReaderPool.Get().(*gzip.Reader); ok {
+		if err := gr.Reset(body); err != nil {
+			gzipReaderPool.Put(gr)
+			return nil, err
+		}
+		return &pooledGzipReader{gr: gr}, nil
+	}
+	gr, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+	return &pooledGzipReader{gr: gr}, nil
+}
+
+func (r *pooledGzipReader) Read(p []byte) (int, error) {
+	if r.gr == nil {
+		return 0, io.ErrClosedPipe
+	}
+	return r.gr.Read(p)
+}
+
+func (r *pooledGzipReader) Close() error {
+	if r.gr == nil {
+		return nil
+	}
+	err := r.gr.Close()
+	gzipReaderPool.Put(r.gr)
+	r.gr = nil
+	return err
+}
+
+// HTTPContentCompressor is a middleware that compresses the responses with gzip for the
+// clients accepting it in the "Accept-Encoding" header. The responses to the other clients,
+// and the responses without a body, are left uncompressed.
+func HTTPContentCompressor(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		defer gw.close()
+		h.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip returns whether the "Accept-Encoding" header of the request allows gzip.
+// An explicit gzip entry takes precedence over the "*" wildcard, and a quality value
+// of 0 refuses the encoding.
+func acceptsGzip(r *http.Request) bool {
+	gzipQ, wildcardQ := -1.0, -1.0
+	for _, v := range r.Header.Values("Accept-Encoding") {
+		for _, element := range strings.Split(v, ",") {
+			coding, q, ok := parseAcceptEncoding(element)
+			if !ok {
+				continue
+			}
+			switch coding {
+			case "gzip":
+				gzipQ = math.Max(gzipQ, q)
+			case "*":
+				wildcardQ = math.Max(wildcardQ, q)
+			}
+		}
+	}
+	if gzipQ >= 0 {
+		return gzipQ > 0
+	}
+	return wildcardQ > 0
+}
+
+// parseAcceptEncoding returns the lower-cased content-coding of an element of the
+// "Accept-Encoding" header and its quality value, 1 when not set. It returns false
+// if the quality value is malformed.
+func parseAcceptEncoding(element string) (string, float64, bool) {
+	params := strings.Split(element, ";")
+	coding := strings.ToLower(strings.TrimSpace(params[0]))
+	q := 1.0
+	for _, param := range params[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 || strings.ToLower(strings.TrimSpace(kv[0])) != "q" {
+			continue
+		}
+		var err error
+		if q, err = strconv.ParseFloat(strings.TrimSpace(kv[1]), 64); err != nil {
+			return "", 0, false
+		}
+	}
+	return coding, q, true
+}
+
+// gzipWriterPool holds the gzip writers released by the responses already compressed.
+var gzipWriterPool sync.Pool
+
+// gzipResponseWriter compresses the body written to the wrapped http.ResponseWriter.
+// The status code is held until the first write of the body, so that a response
+// without a body is sent without the "Content-Encoding" header.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gw          *gzip.Writer
+	statusCode  int
+	wroteHeader bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.statusCode = statusCode
+		w.wroteHeader = true
+	}
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.gw == nil {
+		if len(b) == 0 {
+			return 0, nil
+		}
+		w.wroteHeader = true
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		if gw, ok := gzipWriterPool.Get().(*gzip.Writer); ok {
+			gw.Reset(w.ResponseWriter)
+			w.gw = gw
+		} else {
+			w.gw = gzip.NewWriter(w.ResponseWriter)
+		}
+	}
+	return w.gw.Write(b)
+}
+
+// close flushes the compressed body, or sends the held status code if no body was written.
+func (w *gzipResponseWriter) close() {
+	if w.gw == nil {
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		return
+	}
+	_ = w.gw.Close()
+	gzipWriterPool.Put(w.gw)
+	w.gw = nil
+}
+
 // defaultErrorHandler writes the error message in plain text.
 func defaultErrorHandler(w http.ResponseWriter, _ *http.Request, errMsg string, statusCode int) {
 	http.Error(w, errMsg, statusCode)
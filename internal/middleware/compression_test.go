@@ -19,9 +19,12 @@ import (
 	"compress/gzip"
 	"compress/zlib"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -128,6 +131,177 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 	}
 }
 
+func TestHTTPContentDecompressionReusesGzipReaders(t *testing.T) {
+	handler := HTTPContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				want := fmt.Sprintf("request %d-%d", i, j)
+				reqBody, err := compressGzip([]byte(want))
+				require.NoError(t, err)
+				req := httptest.NewRequest("POST", "/", reqBody)
+				req.Header.Set("Content-Encoding", "gzip")
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, want, rec.Body.String())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// A reader failing to reset on an invalid body is not handed out again broken.
+	req := httptest.NewRequest("POST", "/", bytes.NewBufferString("not gzip"))
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	reqBody, err := compressGzip([]byte("valid"))
+	require.NoError(t, err)
+	req = httptest.NewRequest("POST", "/", reqBody)
+	req.Header.Set("Content-Encoding", "gzip")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "valid", rec.Body.String())
+}
+
+func TestPooledGzipReaderReadAfterClose(t *testing.T) {
+	body, err := compressGzip([]byte("uncompressed_text"))
+	require.NoError(t, err)
+	r, err := newGzipReader(body)
+	require.NoError(t, err)
+	got, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "uncompressed_text", string(got))
+
+	require.NoError(t, r.Close())
+	require.NoError(t, r.Close())
+	n, err := r.Read(make([]byte, 1))
+	assert.Zero(t, n)
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		acceptEncoding []string
+		want           bool
+	}{
+		{acceptEncoding: nil, want: false},
+		{acceptEncoding: []string{"gzip"}, want: true},
+		{acceptEncoding: []string{"GZIP"}, want: true},
+		{acceptEncoding: []string{"deflate, gzip;q=0.5"}, want: true},
+		{acceptEncoding: []string{"gzip; q=0.001"}, want: true},
+		{acceptEncoding: []string{"gzip;q=0"}, want: false},
+		{acceptEncoding: []string{"gzip;q=0.0"}, want: false},
+		{acceptEncoding: []string{"gzip;q=0.000"}, want: false},
+		{acceptEncoding: []string{"gzip; Q = 0"}, want: false},
+		{acceptEncoding: []string{"gzip;q=invalid"}, want: false},
+		{acceptEncoding: []string{"br"}, want: false},
+		{acceptEncoding: []string{"*"}, want: true},
+		{acceptEncoding: []string{"*;q=0.5"}, want: true},
+		{acceptEncoding: []string{"*;q=0"}, want: false},
+		{acceptEncoding: []string{"gzip, *;q=0"}, want: true},
+		{acceptEncoding: []string{"gzip;q=0, *"}, want: false},
+		{acceptEncoding: []string{"br", "gzip"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.acceptEncoding), func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", nil)
+			for _, v := range tt.acceptEncoding {
+				req.Header.Add("Accept-Encoding", v)
+			}
+			assert.Equal(t, tt.want, acceptsGzip(req))
+		})
+	}
+}
+
+func TestHTTPContentCompressor(t *testing.T) {
+	body := []byte("uncompressed_text")
+	handler := HTTPContentCompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/empty" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(body)
+	}))
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		status         int
+		compressed     bool
+	}{
+		{name: "NoAcceptEncoding", path: "/", status: http.StatusBadRequest},
+		{name: "Gzip", path: "/", acceptEncoding: "gzip", status: http.StatusBadRequest, compressed: true},
+		{name: "GzipInList", path: "/", acceptEncoding: "deflate, gzip;q=0.5", status: http.StatusBadRequest, compressed: true},
+		{name: "GzipRefused", path: "/", acceptEncoding: "gzip;q=0", status: http.StatusBadRequest},
+		{name: "OtherEncoding", path: "/", acceptEncoding: "br", status: http.StatusBadRequest},
+		{name: "NoBody", path: "/empty", acceptEncoding: "gzip", status: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			if tt.status == http.StatusNoContent {
+				assert.Empty(t, rec.Header().Get("Content-Encoding"))
+				assert.Zero(t, rec.Body.Len())
+				return
+			}
+			if !tt.compressed {
+				assert.Empty(t, rec.Header().Get("Content-Encoding"))
+				assert.Equal(t, body, rec.Body.Bytes())
+				return
+			}
+			assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+			assert.Empty(t, rec.Header().Get("Content-Length"))
+			gr, err := gzip.NewReader(rec.Body)
+			require.NoError(t, err)
+			got, err := ioutil.ReadAll(gr)
+			require.NoError(t, err)
+			assert.Equal(t, body, got)
+		})
+	}
+}
+
+func BenchmarkHTTPContentDecompressorGzip(b *testing.B) {
+	handler := HTTPContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, r.Body)
+	}))
+	body, err := compressGzip(bytes.Repeat([]byte("uncompressed_text"), 1024))
+	require.NoError(b, err)
+	compressed := body.Bytes()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(compressed))
+		req.Header.Set("Content-Encoding", "gzip")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
+
 func compressGzip(body []byte) (*bytes.Buffer, error) {
 	var buf bytes.Buffer
 
@@ -93,12 +93,19 @@ func AllViews() (views []*view.View) {
 		mReceiverRefusedMetricPoints,
 		mReceiverAcceptedLogRecords,
 		mReceiverRefusedLogRecords,
+		mReceiverOpenConnections,
 	}
 	tagKeys := []tag.Key{
 		tagKeyReceiver, tagKeyTransport,
 	}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	measures = []*stats.Int64Measure{mReceiverRequests}
+	tagKeys = []tag.Key{
+		tagKeyReceiver, tagKeyTransport, tagKeySignal, tagKeyStatus,
+	}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+
 	// Scraper views.
 	measures = []*stats.Int64Measure{
 		mScraperScrapedMetricPoints,
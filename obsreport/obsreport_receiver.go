@@ -49,11 +49,23 @@ const (
 	// Key used to identify log records refused (ie.: not ingested) by the
 	// Collector.
 	RefusedLogRecordsKey = "refused_log_records"
+
+	// Key used to identify the connections open to a receiver.
+	OpenConnectionsKey = "open_connections"
+
+	// Key used to identify the requests handled by a receiver.
+	RequestsKey = "requests"
+	// Key used to identify the signal of the data in a request.
+	SignalKey = "signal"
+	// Key used to identify the class of a response status, e.g. "2xx".
+	StatusClassKey = "status_class"
 )
 
 var (
 	tagKeyReceiver, _  = tag.NewKey(ReceiverKey)
 	tagKeyTransport, _ = tag.NewKey(TransportKey)
+	tagKeySignal, _    = tag.NewKey(SignalKey)
+	tagKeyStatus, _    = tag.NewKey(StatusClassKey)
 
 	receiverPrefix                  = ReceiverKey + nameSep
 	receiveTraceDataOperationSuffix = nameSep + "TraceDataReceived"
@@ -89,6 +101,14 @@ var (
 		receiverPrefix+RefusedLogRecordsKey,
 		"Number of log records that could not be pushed into the pipeline.",
 		stats.UnitDimensionless)
+	mReceiverOpenConnections = stats.Int64(
+		receiverPrefix+OpenConnectionsKey,
+		"Number of connections currently open to the receiver.",
+		stats.UnitDimensionless)
+	mReceiverRequests = stats.Int64(
+		receiverPrefix+RequestsKey,
+		"Number of requests handled by the receiver by response status class.",
+		stats.UnitDimensionless)
 )
 
 // StartReceiveOptions has the options related to starting a receive operation.
@@ -245,6 +265,40 @@ func EndMetricsReceiveOp(
 	)
 }
 
+// ReceiverConnectionOpened records that a client opened a connection to the
+// receiver. The receiverCtx must be created with ReceiverContext.
+func ReceiverConnectionOpened(receiverCtx context.Context) {
+	recordOpenConnections(receiverCtx, 1)
+}
+
+// ReceiverConnectionClosed records that a connection recorded with
+// ReceiverConnectionOpened was closed.
+func ReceiverConnectionClosed(receiverCtx context.Context) {
+	recordOpenConnections(receiverCtx, -1)
+}
+
+func recordOpenConnections(receiverCtx context.Context, delta int64) {
+	if gLevel != configtelemetry.LevelNone {
+		stats.Record(receiverCtx, mReceiverOpenConnections.M(delta))
+	}
+}
+
+// ReceiverRequestCompleted records a request carrying the given signal that
+// the receiver answered with a response status of the given class, e.g. "2xx"
+// or "4xx". The receiverCtx must be created with ReceiverContext.
+func ReceiverRequestCompleted(receiverCtx context.Context, signal configmodels.DataType, statusClass string) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	_ = stats.RecordWithTags(
+		receiverCtx,
+		[]tag.Mutator{
+			tag.Upsert(tagKeySignal, string(signal), tag.WithTTL(tag.TTLNoPropagation)),
+			tag.Upsert(tagKeyStatus, statusClass, tag.WithTTL(tag.TTLNoPropagation)),
+		},
+		mReceiverRequests.M(1))
+}
+
 // ReceiverContext adds the keys used when recording observability metrics to
 // the given context returning the newly created context. This context should
 // be used in related calls to the obsreport functions so metrics are properly
@@ -28,6 +28,7 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/trace"
 
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
@@ -542,6 +543,36 @@ func TestReceiveWithLongLivedCtx(t *testing.T) {
 	}
 }
 
+func TestReceiverConnections(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	receiverCtx := obsreport.ReceiverContext(context.Background(), receiver, transport)
+	obsreport.ReceiverConnectionOpened(receiverCtx)
+	obsreport.ReceiverConnectionOpened(receiverCtx)
+	obsreporttest.CheckReceiverConnectionsViews(t, receiver, transport, 2)
+
+	obsreport.ReceiverConnectionClosed(receiverCtx)
+	obsreporttest.CheckReceiverConnectionsViews(t, receiver, transport, 1)
+}
+
+func TestReceiverRequests(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	receiverCtx := obsreport.ReceiverContext(context.Background(), receiver, transport)
+	obsreport.ReceiverRequestCompleted(receiverCtx, configmodels.TracesDataType, "2xx")
+	obsreport.ReceiverRequestCompleted(receiverCtx, configmodels.TracesDataType, "2xx")
+	obsreport.ReceiverRequestCompleted(receiverCtx, configmodels.TracesDataType, "4xx")
+	obsreport.ReceiverRequestCompleted(receiverCtx, configmodels.LogsDataType, "5xx")
+
+	obsreporttest.CheckReceiverRequestsViews(t, receiver, transport, "traces", "2xx", 2)
+	obsreporttest.CheckReceiverRequestsViews(t, receiver, transport, "traces", "4xx", 1)
+	obsreporttest.CheckReceiverRequestsViews(t, receiver, transport, "logs", "5xx", 1)
+}
+
 func TestProcessorTraceData(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
@@ -37,6 +37,8 @@ var (
 	receiverTag, _  = tag.NewKey("receiver")
 	scraperTag, _   = tag.NewKey("scraper")
 	transportTag, _ = tag.NewKey("transport")
+	signalTag, _    = tag.NewKey("signal")
+	statusTag, _    = tag.NewKey("status_class")
 	exporterTag, _  = tag.NewKey("exporter")
 	processorTag, _ = tag.NewKey("processor")
 )
@@ -130,6 +132,23 @@ func CheckReceiverMetricsViews(t *testing.T, receiver, protocol string, accepted
 	CheckValueForView(t, receiverTags, droppedMetricPoints, "receiver/refused_metric_points")
 }
 
+// CheckReceiverConnectionsViews checks that the current number of connections open to the receiver matches the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckReceiverConnectionsViews(t *testing.T, receiver, protocol string, openConnections int64) {
+	receiverTags := tagsForReceiverView(receiver, protocol)
+	CheckValueForView(t, receiverTags, openConnections, "receiver/open_connections")
+}
+
+// CheckReceiverRequestsViews checks that the number of requests of the given signal answered with the given status class
+// matches the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckReceiverRequestsViews(t *testing.T, receiver, protocol, signal, statusClass string, requests int64) {
+	receiverTags := append(tagsForReceiverView(receiver, protocol),
+		tag.Tag{Key: signalTag, Value: signal},
+		tag.Tag{Key: statusTag, Value: statusClass})
+	CheckValueForView(t, receiverTags, requests, "receiver/requests")
+}
+
 // CheckScraperMetricsViews checks that for the current exported values for metrics scraper views match given values.
 // When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
 func CheckScraperMetricsViews(t *testing.T, receiver, scraper string, scrapedMetricPoints, erroredMetricPoints int64) {
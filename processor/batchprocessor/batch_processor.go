@@ -17,6 +17,7 @@ package batchprocessor
 import (
 	"context"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/stats"
@@ -46,14 +47,52 @@ type batchProcessor struct {
 	sendBatchSize    uint32
 	timeout          time.Duration
 	sendBatchMaxSize uint32
-
-	timer   *time.Timer
-	done    chan struct{}
-	newItem chan interface{}
-	batch   batch
+	splitTrace       func(size int, toSplit pdata.Traces) pdata.Traces
+	splitMetrics     bool
+	exportTimeout    time.Duration
+	maxInFlightItems uint32
+	// requestCountAttribute is the resource attribute set to the number of
+	// requests merged in a batch, empty if disabled.
+	requestCountAttribute string
+
+	// inFlightItems is the number of items accepted but not yet exported,
+	// only tracked when maxInFlightItems is set.
+	inFlightItems int64
+
+	// newTimer creates the timer flushing the batch on timeout, tests replace
+	// it before Start to fire the timeout deterministically.
+	newTimer func(d time.Duration) batchTimer
+	timer    batchTimer
+	done     chan struct{}
+	newItem  chan interface{}
+	batch    batch
 
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	// shutdownCtx is the context passed to Shutdown, it is published to the
+	// processing goroutine by the cancellation of ctx.
+	shutdownCtx context.Context
+}
+
+// batchTimer is the subset of *time.Timer used by the processing cycle.
+type batchTimer interface {
+	// C returns the channel on which the timeouts are delivered.
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func newRealTimer(d time.Duration) batchTimer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
 }
 
 type batch interface {
@@ -63,7 +102,8 @@ type batch interface {
 	// itemCount returns the size of the current batch
 	itemCount() uint32
 
-	// size returns the size in bytes of the current batch
+	// size returns the size in bytes of the current batch, it is maintained
+	// incrementally by add so that calling it does not re-measure the batch.
 	size() int
 
 	// reset the current batch structure with zero/empty values.
@@ -71,6 +111,12 @@ type batch interface {
 
 	// add item to the current batch
 	add(item interface{})
+
+	// requestCount returns the number of non-empty items added to the current batch.
+	requestCount() int
+
+	// upsertResourceAttribute sets the attribute on every resource of the current batch.
+	upsertResourceAttribute(key string, value int64)
 }
 
 var _ consumer.TracesConsumer = (*batchProcessor)(nil)
@@ -79,6 +125,10 @@ var _ consumer.LogsConsumer = (*batchProcessor)(nil)
 
 func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, batch batch, telemetryLevel configtelemetry.Level) *batchProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
+	split := splitTrace
+	if cfg.KeepTracesTogether {
+		split = splitTraceByTraceID
+	}
 	return &batchProcessor{
 		name:           cfg.Name(),
 		logger:         params.Logger,
@@ -86,12 +136,19 @@ func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, batc
 
 		sendBatchSize:    cfg.SendBatchSize,
 		sendBatchMaxSize: cfg.SendBatchMaxSize,
+		splitTrace:       split,
+		splitMetrics:     cfg.SplitMetrics,
 		timeout:          cfg.Timeout,
+		exportTimeout:    cfg.ExportTimeout,
+		maxInFlightItems: cfg.MaxInFlightItems,
+		newTimer:         newRealTimer,
 		done:             make(chan struct{}, 1),
 		newItem:          make(chan interface{}, runtime.NumCPU()),
 		batch:            batch,
 		ctx:              ctx,
 		cancel:           cancel,
+
+		requestCountAttribute: cfg.RequestCountAttribute,
 	}
 }
 
@@ -105,15 +162,27 @@ func (bp *batchProcessor) Start(context.Context, component.Host) error {
 	return nil
 }
 
-// Shutdown is invoked during service shutdown.
-func (bp *batchProcessor) Shutdown(context.Context) error {
+// Shutdown is invoked during service shutdown. The buffered items are exported
+// before it returns, unless ctx is done first, in which case the context error is
+// returned even if the next consumer does not honor the cancellation. The buffered
+// items are then abandoned by Shutdown but the processing goroutine keeps
+// exporting them until the next consumer returns. A drain that completes is
+// reported as a success.
+func (bp *batchProcessor) Shutdown(ctx context.Context) error {
+	bp.shutdownCtx = ctx
 	bp.cancel()
-	<-bp.done
-	return nil
+	select {
+	case <-bp.done:
+		return nil
+	case <-ctx.Done():
+		bp.logger.Warn("Shutdown context done before the buffered items were exported, they may still be exported after Shutdown returns",
+			zap.String("processor", bp.name), zap.Error(ctx.Err()))
+		return ctx.Err()
+	}
 }
 
 func (bp *batchProcessor) startProcessingCycle() {
-	bp.timer = time.NewTimer(bp.timeout)
+	bp.timer = bp.newTimer(bp.timeout)
 	for {
 		select {
 		case <-bp.ctx.Done():
@@ -121,16 +190,14 @@ func (bp *batchProcessor) startProcessingCycle() {
 			for {
 				select {
 				case item := <-bp.newItem:
-					bp.processItem(item)
+					bp.processItem(bp.shutdownCtx, item)
 				default:
 					break DONE
 				}
 			}
 			// This is the close of the channel
 			if bp.batch.itemCount() > 0 {
-				// TODO: Set a timeout on sendTraces or
-				// make it cancellable using the context that Shutdown gets as a parameter
-				bp.sendItems(statTimeoutTriggerSend)
+				bp.sendItems(bp.shutdownCtx, statTimeoutTriggerSend)
 			}
 			close(bp.done)
 			return
@@ -138,25 +205,47 @@ func (bp *batchProcessor) startProcessingCycle() {
 			if item == nil {
 				continue
 			}
-			bp.processItem(item)
-		case <-bp.timer.C:
+			bp.processItem(bp.exportContext(), item)
+		case <-bp.timer.C():
 			if bp.batch.itemCount() > 0 {
-				bp.sendItems(statTimeoutTriggerSend)
+				bp.sendItems(bp.exportContext(), statTimeoutTriggerSend)
 			}
 			bp.resetTimer()
 		}
 	}
 }
 
-func (bp *batchProcessor) processItem(item interface{}) {
+// exportContext returns the context that bounds exports, the context passed to
+// Shutdown once the shutdown has started.
+func (bp *batchProcessor) exportContext() context.Context {
+	select {
+	case <-bp.ctx.Done():
+		return bp.shutdownCtx
+	default:
+		return context.Background()
+	}
+}
+
+func (bp *batchProcessor) processItem(ctx context.Context, item interface{}) {
 	if bp.sendBatchMaxSize > 0 {
 		if td, ok := item.(pdata.Traces); ok {
 			itemCount := bp.batch.itemCount()
 			if itemCount+uint32(td.SpanCount()) > bp.sendBatchMaxSize {
-				tdRemainSize := splitTrace(int(bp.sendBatchSize-itemCount), td)
+				tdRemainSize := bp.splitTrace(int(bp.sendBatchSize-itemCount), td)
 				item = tdRemainSize
+				if td.SpanCount() > 0 {
+					go func() {
+						bp.newItem <- td
+					}()
+				}
+			}
+		}
+		if md, ok := item.(pdata.Metrics); ok && bp.splitMetrics {
+			itemCount := bp.batch.itemCount()
+			if itemCount+uint32(md.MetricCount()) > bp.sendBatchMaxSize {
+				item = splitMetrics(int(bp.sendBatchSize-itemCount), md)
 				go func() {
-					bp.newItem <- td
+					bp.newItem <- md
 				}()
 			}
 		}
@@ -165,7 +254,7 @@ func (bp *batchProcessor) processItem(item interface{}) {
 	bp.batch.add(item)
 	if bp.batch.itemCount() >= bp.sendBatchSize {
 		bp.timer.Stop()
-		bp.sendItems(statBatchSizeTriggerSend)
+		bp.sendItems(ctx, statBatchSizeTriggerSend)
 		bp.resetTimer()
 	}
 }
@@ -174,7 +263,7 @@ func (bp *batchProcessor) resetTimer() {
 	bp.timer.Reset(bp.timeout)
 }
 
-func (bp *batchProcessor) sendItems(measure *stats.Int64Measure) {
+func (bp *batchProcessor) sendItems(ctx context.Context, measure *stats.Int64Measure) {
 	// Add that it came form the trace pipeline?
 	statsTags := []tag.Mutator{tag.Insert(processor.TagProcessorNameKey, bp.name)}
 	_ = stats.RecordWithTags(context.Background(), statsTags, measure.M(1), statBatchSendSize.M(int64(bp.batch.itemCount())))
@@ -183,50 +272,146 @@ func (bp *batchProcessor) sendItems(measure *stats.Int64Measure) {
 		_ = stats.RecordWithTags(context.Background(), statsTags, statBatchSendSizeBytes.M(int64(bp.batch.size())))
 	}
 
-	if err := bp.batch.export(context.Background()); err != nil {
+	if bp.exportTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, bp.exportTimeout)
+		defer cancel()
+	}
+
+	if bp.maxInFlightItems > 0 {
+		atomic.AddInt64(&bp.inFlightItems, -int64(bp.batch.itemCount()))
+	}
+
+	if bp.requestCountAttribute != "" {
+		bp.batch.upsertResourceAttribute(bp.requestCountAttribute, int64(bp.batch.requestCount()))
+	}
+
+	if err := bp.batch.export(ctx); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			_ = stats.RecordWithTags(context.Background(), statsTags, statExportTimeout.M(1))
+		}
 		bp.logger.Warn("Sender failed", zap.Error(err))
 	}
 	bp.batch.reset()
 }
 
+// enqueue hands the item to the processing goroutine. When maxInFlightItems is set
+// the caller is never blocked: the oldest buffered items are dropped to make room,
+// and the item itself is dropped if it can not fit.
+func (bp *batchProcessor) enqueue(item interface{}, count int) {
+	if bp.maxInFlightItems == 0 {
+		bp.newItem <- item
+		return
+	}
+
+	// An item larger than the cap can never be admitted.
+	if count > int(bp.maxInFlightItems) {
+		bp.recordDropped(count)
+		return
+	}
+	for !bp.reserve(count) {
+		// Nothing is buffered, all in-flight items are in the batch being built
+		// or exported, so there is no older item left to drop.
+		if !bp.dropOldest() {
+			bp.recordDropped(count)
+			return
+		}
+	}
+	for {
+		select {
+		case bp.newItem <- item:
+			return
+		default:
+			bp.dropOldest()
+		}
+	}
+}
+
+// reserve atomically accounts count items as in-flight, it returns false if that
+// would exceed maxInFlightItems.
+func (bp *batchProcessor) reserve(count int) bool {
+	for {
+		inFlight := atomic.LoadInt64(&bp.inFlightItems)
+		if inFlight+int64(count) > int64(bp.maxInFlightItems) {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&bp.inFlightItems, inFlight, inFlight+int64(count)) {
+			return true
+		}
+	}
+}
+
+// dropOldest drops the oldest buffered item, it returns false if there was none.
+func (bp *batchProcessor) dropOldest() bool {
+	select {
+	case item := <-bp.newItem:
+		count := countItems(item)
+		atomic.AddInt64(&bp.inFlightItems, -int64(count))
+		bp.recordDropped(count)
+		return true
+	default:
+		return false
+	}
+}
+
+func (bp *batchProcessor) recordDropped(count int) {
+	statsTags := []tag.Mutator{tag.Insert(processor.TagProcessorNameKey, bp.name)}
+	_ = stats.RecordWithTags(context.Background(), statsTags, statDroppedItems.M(int64(count)))
+}
+
+// countItems returns the number of spans, metrics or log records in the item.
+func countItems(item interface{}) int {
+	switch it := item.(type) {
+	case pdata.Traces:
+		return it.SpanCount()
+	case pdata.Metrics:
+		return it.MetricCount()
+	case pdata.Logs:
+		return it.LogRecordCount()
+	}
+	return 0
+}
+
 // ConsumeTraces implements TracesProcessor
 func (bp *batchProcessor) ConsumeTraces(_ context.Context, td pdata.Traces) error {
-	bp.newItem <- td
+	bp.enqueue(td, td.SpanCount())
 	return nil
 }
 
 // ConsumeTraces implements MetricsProcessor
 func (bp *batchProcessor) ConsumeMetrics(_ context.Context, md pdata.Metrics) error {
 	// First thing is convert into a different internal format
-	bp.newItem <- md
+	bp.enqueue(md, md.MetricCount())
 	return nil
 }
 
 // ConsumeLogs implements LogsProcessor
 func (bp *batchProcessor) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
-	bp.newItem <- ld
+	bp.enqueue(ld, ld.LogRecordCount())
 	return nil
 }
 
 // newBatchTracesProcessor creates a new batch processor that batches traces by size or with timeout
 func newBatchTracesProcessor(params component.ProcessorCreateParams, trace consumer.TracesConsumer, cfg *Config, telemetryLevel configtelemetry.Level) *batchProcessor {
-	return newBatchProcessor(params, cfg, newBatchTraces(trace), telemetryLevel)
+	return newBatchProcessor(params, cfg.withSignalSettings(cfg.Traces), newBatchTraces(trace), telemetryLevel)
 }
 
 // newBatchMetricsProcessor creates a new batch processor that batches metrics by size or with timeout
 func newBatchMetricsProcessor(params component.ProcessorCreateParams, metrics consumer.MetricsConsumer, cfg *Config, telemetryLevel configtelemetry.Level) *batchProcessor {
-	return newBatchProcessor(params, cfg, newBatchMetrics(metrics), telemetryLevel)
+	return newBatchProcessor(params, cfg.withSignalSettings(cfg.Metrics), newBatchMetrics(metrics), telemetryLevel)
 }
 
 // newBatchLogsProcessor creates a new batch processor that batches logs by size or with timeout
 func newBatchLogsProcessor(params component.ProcessorCreateParams, logs consumer.LogsConsumer, cfg *Config, telemetryLevel configtelemetry.Level) *batchProcessor {
-	return newBatchProcessor(params, cfg, newBatchLogs(logs), telemetryLevel)
+	return newBatchProcessor(params, cfg.withSignalSettings(cfg.Logs), newBatchLogs(logs), telemetryLevel)
 }
 
 type batchTraces struct {
 	nextConsumer consumer.TracesConsumer
 	traceData    pdata.Traces
 	spanCount    uint32
+	sizeBytes    int
+	requests     int
 }
 
 func newBatchTraces(nextConsumer consumer.TracesConsumer) *batchTraces {
@@ -244,6 +429,8 @@ func (bt *batchTraces) add(item interface{}) {
 	}
 
 	bt.spanCount += uint32(newSpanCount)
+	bt.requests++
+	bt.sizeBytes += td.Size()
 	td.ResourceSpans().MoveAndAppendTo(bt.traceData.ResourceSpans())
 }
 
@@ -256,19 +443,34 @@ func (bt *batchTraces) itemCount() uint32 {
 }
 
 func (bt *batchTraces) size() int {
-	return bt.traceData.Size()
+	return bt.sizeBytes
 }
 
 // resets the current batchTraces structure with zero values
 func (bt *batchTraces) reset() {
 	bt.traceData = pdata.NewTraces()
 	bt.spanCount = 0
+	bt.sizeBytes = 0
+	bt.requests = 0
+}
+
+func (bt *batchTraces) requestCount() int {
+	return bt.requests
+}
+
+func (bt *batchTraces) upsertResourceAttribute(key string, value int64) {
+	rss := bt.traceData.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rss.At(i).Resource().Attributes().UpsertInt(key, value)
+	}
 }
 
 type batchMetrics struct {
 	nextConsumer consumer.MetricsConsumer
 	metricData   pdata.Metrics
 	metricCount  uint32
+	sizeBytes    int
+	requests     int
 }
 
 func newBatchMetrics(nextConsumer consumer.MetricsConsumer) *batchMetrics {
@@ -286,13 +488,26 @@ func (bm *batchMetrics) itemCount() uint32 {
 }
 
 func (bm *batchMetrics) size() int {
-	return bm.metricData.Size()
+	return bm.sizeBytes
 }
 
 // resets the current batchMetrics structure with zero/empty values.
 func (bm *batchMetrics) reset() {
 	bm.metricData = pdata.NewMetrics()
 	bm.metricCount = 0
+	bm.sizeBytes = 0
+	bm.requests = 0
+}
+
+func (bm *batchMetrics) requestCount() int {
+	return bm.requests
+}
+
+func (bm *batchMetrics) upsertResourceAttribute(key string, value int64) {
+	rms := bm.metricData.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rms.At(i).Resource().Attributes().UpsertInt(key, value)
+	}
 }
 
 func (bm *batchMetrics) add(item interface{}) {
@@ -303,6 +518,8 @@ func (bm *batchMetrics) add(item interface{}) {
 		return
 	}
 	bm.metricCount += uint32(newMetricsCount)
+	bm.requests++
+	bm.sizeBytes += md.Size()
 	md.ResourceMetrics().MoveAndAppendTo(bm.metricData.ResourceMetrics())
 }
 
@@ -310,6 +527,8 @@ type batchLogs struct {
 	nextConsumer consumer.LogsConsumer
 	logData      pdata.Logs
 	logCount     uint32
+	sizeBytes    int
+	requests     int
 }
 
 func newBatchLogs(nextConsumer consumer.LogsConsumer) *batchLogs {
@@ -327,13 +546,26 @@ func (bm *batchLogs) itemCount() uint32 {
 }
 
 func (bm *batchLogs) size() int {
-	return bm.logData.SizeBytes()
+	return bm.sizeBytes
 }
 
 // resets the current batchLogs structure with zero/empty values.
 func (bm *batchLogs) reset() {
 	bm.logData = pdata.NewLogs()
 	bm.logCount = 0
+	bm.sizeBytes = 0
+	bm.requests = 0
+}
+
+func (bm *batchLogs) requestCount() int {
+	return bm.requests
+}
+
+func (bm *batchLogs) upsertResourceAttribute(key string, value int64) {
+	rls := bm.logData.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rls.At(i).Resource().Attributes().UpsertInt(key, value)
+	}
 }
 
 func (bm *batchLogs) add(item interface{}) {
@@ -344,5 +576,7 @@ func (bm *batchLogs) add(item interface{}) {
 		return
 	}
 	bm.logCount += uint32(newLogsCount)
+	bm.requests++
+	bm.sizeBytes += ld.SizeBytes()
 	ld.ResourceLogs().MoveAndAppendTo(bm.logData.ResourceLogs())
 }
@@ -17,6 +17,9 @@ package batchprocessor
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -24,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -225,6 +229,128 @@ func TestBatchProcessorSentByTimeout(t *testing.T) {
 	}
 }
 
+func TestBatchProcessorRequestCountAttribute(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RequestCountAttribute = "batch.request_count"
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	checkResources := func(t *testing.T, resources []pdata.Resource, want int64) {
+		require.NotEmpty(t, resources)
+		for _, r := range resources {
+			v, ok := r.Attributes().Get(cfg.RequestCountAttribute)
+			require.True(t, ok)
+			assert.Equal(t, want, v.IntVal())
+		}
+	}
+
+	t.Run("traces", func(t *testing.T) {
+		sink := new(consumertest.TracesSink)
+		batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelDetailed)
+		require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+		for i := 0; i < 3; i++ {
+			require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource()))
+		}
+		// Empty requests are not merged in the batch.
+		require.NoError(t, batcher.ConsumeTraces(context.Background(), pdata.NewTraces()))
+		require.NoError(t, batcher.Shutdown(context.Background()))
+
+		require.Len(t, sink.AllTraces(), 1)
+		var resources []pdata.Resource
+		rss := sink.AllTraces()[0].ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			resources = append(resources, rss.At(i).Resource())
+		}
+		assert.Len(t, resources, 3)
+		checkResources(t, resources, 3)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		sink := new(consumertest.MetricsSink)
+		batcher := newBatchMetricsProcessor(creationParams, sink, cfg, configtelemetry.LevelDetailed)
+		require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+		for i := 0; i < 2; i++ {
+			require.NoError(t, batcher.ConsumeMetrics(context.Background(), testdata.GenerateMetricsTwoMetrics()))
+		}
+		require.NoError(t, batcher.Shutdown(context.Background()))
+
+		require.Len(t, sink.AllMetrics(), 1)
+		var resources []pdata.Resource
+		rms := sink.AllMetrics()[0].ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			resources = append(resources, rms.At(i).Resource())
+		}
+		checkResources(t, resources, 2)
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		sink := new(consumertest.LogsSink)
+		batcher := newBatchLogsProcessor(creationParams, sink, cfg, configtelemetry.LevelDetailed)
+		require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+		for i := 0; i < 5; i++ {
+			require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+		}
+		require.NoError(t, batcher.Shutdown(context.Background()))
+
+		require.Len(t, sink.AllLogs(), 1)
+		var resources []pdata.Resource
+		rls := sink.AllLogs()[0].ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			resources = append(resources, rls.At(i).Resource())
+		}
+		checkResources(t, resources, 5)
+	})
+}
+
+// fakeTimer is a batchTimer firing only when the test calls fire.
+type fakeTimer struct {
+	c chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time      { return t.c }
+func (t *fakeTimer) Stop() bool               { return true }
+func (t *fakeTimer) Reset(time.Duration) bool { return true }
+
+// fire blocks until the processing cycle receives the timeout.
+func (t *fakeTimer) fire() {
+	t.c <- time.Now()
+}
+
+func TestBatchProcessorSentByFakeTimeout(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 100
+	cfg.Timeout = time.Hour
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelDetailed)
+	timer := &fakeTimer{c: make(chan time.Time)}
+	var timeout time.Duration
+	batcher.newTimer = func(d time.Duration) batchTimer {
+		timeout = d
+		return timer
+	}
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for requestNum := 0; requestNum < 5; requestNum++ {
+		td := testdata.GenerateTraceDataManySpansSameResource(10)
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	}
+	// Once the queue is empty the cycle has nothing but the timeout left to
+	// select, so every item is in the batch when the timeout is handled.
+	for len(batcher.newItem) > 0 {
+		runtime.Gosched()
+	}
+	timer.fire()
+	// The next timeout is only received after the batch was sent.
+	timer.fire()
+	assert.Equal(t, cfg.Timeout, timeout)
+	assert.Equal(t, 50, sink.SpansCount())
+	assert.Len(t, sink.AllTraces(), 1)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
 func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	cfg := Config{
 		Timeout:       3 * time.Second,
@@ -689,3 +815,458 @@ func logsReceivedByName(lds []pdata.Logs) map[string]pdata.LogRecord {
 	}
 	return logsReceivedByName
 }
+
+// blockingTracesConsumer blocks every export until its context is done.
+type blockingTracesConsumer struct{}
+
+func (blockingTracesConsumer) ConsumeTraces(ctx context.Context, _ pdata.Traces) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestBatchProcessorExportTimeout(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := Config{
+		Timeout:       3 * time.Second,
+		SendBatchSize: 10,
+		ExportTimeout: 50 * time.Millisecond,
+	}
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, blockingTracesConsumer{}, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	start := time.Now()
+	td := testdata.GenerateTraceDataManySpansSameResource(10)
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start).Nanoseconds(), cfg.ExportTimeout.Nanoseconds())
+
+	viewData, err := view.RetrieveData("processor/batch/" + statExportTimeout.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.Equal(t, float64(1), viewData[0].Data.(*view.SumData).Value)
+}
+
+// stuckTracesConsumer blocks the first export until release is closed and every
+// later export until its context is done.
+type stuckTracesConsumer struct {
+	release chan struct{}
+	calls   int32
+}
+
+func (sc *stuckTracesConsumer) ConsumeTraces(ctx context.Context, _ pdata.Traces) error {
+	if atomic.AddInt32(&sc.calls, 1) == 1 {
+		<-sc.release
+		return nil
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestBatchProcessorShutdownContextBoundsExport(t *testing.T) {
+	cfg := Config{
+		Timeout:       3 * time.Second,
+		SendBatchSize: 10,
+	}
+	sink := &stuckTracesConsumer{release: make(chan struct{})}
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The first batch blocks the processing goroutine, so the following ones are
+	// still buffered when Shutdown starts and each crosses send_batch_size while draining.
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(10)))
+	for requestNum := 0; requestNum < 3; requestNum++ {
+		go func() {
+			_ = batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(10))
+		}()
+	}
+	<-time.After(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	shutdownDone := make(chan error, 1)
+	go func() {
+		shutdownDone <- batcher.Shutdown(ctx)
+	}()
+	<-time.After(10 * time.Millisecond)
+	close(sink.release)
+
+	select {
+	case err := <-shutdownDone:
+		assert.Equal(t, context.DeadlineExceeded, err)
+	case <-time.After(cfg.Timeout):
+		t.Fatal("Shutdown did not return within its context deadline")
+	}
+	assert.Greater(t, atomic.LoadInt32(&sink.calls), int32(1))
+}
+
+func TestBatchProcessorShutdownWithConsumerIgnoringContext(t *testing.T) {
+	cfg := Config{
+		Timeout:       3 * time.Second,
+		SendBatchSize: 10,
+	}
+	sink := &gatedTracesConsumer{release: make(chan struct{})}
+	defer close(sink.release)
+
+	core, logs := observer.New(zap.WarnLevel)
+	creationParams := component.ProcessorCreateParams{Logger: zap.New(core)}
+	batcher := newBatchTracesProcessor(creationParams, sink, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(5)))
+
+	// The final export never returns, Shutdown is only bounded by its context.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	shutdownDone := make(chan error, 1)
+	go func() {
+		shutdownDone <- batcher.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-shutdownDone:
+		assert.Equal(t, context.DeadlineExceeded, err)
+	case <-time.After(cfg.Timeout):
+		t.Fatal("Shutdown did not return within its context deadline")
+	}
+	// The abandoned export is logged.
+	assert.Equal(t, 1, logs.FilterMessageSnippet("buffered items were exported").Len())
+}
+
+func TestBatchSizeMaintainedIncrementally(t *testing.T) {
+	bt := newBatchTraces(new(consumertest.TracesSink))
+	bm := newBatchMetrics(new(consumertest.MetricsSink))
+	bl := newBatchLogs(new(consumertest.LogsSink))
+	for i := 1; i <= 10; i++ {
+		bt.add(testdata.GenerateTraceDataManySpansSameResource(i))
+		bm.add(testdata.GenerateMetricsManyMetricsSameResource(i))
+		bl.add(testdata.GenerateLogDataManyLogsSameResource(i))
+	}
+	// Empty payloads are not added to the batch.
+	bt.add(testdata.GenerateTraceDataEmpty())
+	bm.add(testdata.GenerateMetricsEmpty())
+	bl.add(testdata.GenerateLogDataEmpty())
+
+	assert.Equal(t, bt.traceData.Size(), bt.size())
+	assert.Equal(t, bm.metricData.Size(), bm.size())
+	assert.Equal(t, bl.logData.SizeBytes(), bl.size())
+
+	bt.reset()
+	bm.reset()
+	bl.reset()
+	assert.Equal(t, 0, bt.size())
+	assert.Equal(t, 0, bm.size())
+	assert.Equal(t, 0, bl.size())
+}
+
+func TestBatchProcessorSignalSettings(t *testing.T) {
+	cfg := Config{
+		Timeout:       3 * time.Second,
+		SendBatchSize: 1000,
+		Traces: &SignalSettings{
+			Timeout: 50 * time.Millisecond,
+		},
+		Logs: &SignalSettings{
+			Timeout:       500 * time.Millisecond,
+			SendBatchSize: 2000,
+		},
+	}
+	tracesSink := new(consumertest.TracesSink)
+	logsSink := new(consumertest.LogsSink)
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	traces := newBatchTracesProcessor(creationParams, tracesSink, &cfg, configtelemetry.LevelDetailed)
+	logs := newBatchLogsProcessor(creationParams, logsSink, &cfg, configtelemetry.LevelDetailed)
+	assert.Equal(t, 50*time.Millisecond, traces.timeout)
+	assert.Equal(t, uint32(1000), traces.sendBatchSize)
+	assert.Equal(t, 500*time.Millisecond, logs.timeout)
+	assert.Equal(t, uint32(2000), logs.sendBatchSize)
+
+	require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, logs.Start(context.Background(), componenttest.NewNopHost()))
+
+	start := time.Now()
+	assert.NoError(t, traces.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(10)))
+	assert.NoError(t, logs.ConsumeLogs(context.Background(), testdata.GenerateLogDataManyLogsSameResource(10)))
+
+	// Traces are flushed by their own short timeout while logs are still waiting.
+	for {
+		if tracesSink.SpansCount() != 0 {
+			break
+		}
+		<-time.After(10 * time.Millisecond)
+	}
+	assert.Equal(t, 10, tracesSink.SpansCount())
+	assert.Equal(t, 0, logsSink.LogRecordsCount())
+
+	// Logs are flushed by their own longer timeout, well before the shared one.
+	for {
+		if logsSink.LogRecordsCount() != 0 {
+			break
+		}
+		<-time.After(10 * time.Millisecond)
+	}
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed.Nanoseconds(), cfg.Logs.Timeout.Nanoseconds())
+	assert.Less(t, elapsed.Nanoseconds(), cfg.Timeout.Nanoseconds())
+	assert.Equal(t, 10, logsSink.LogRecordsCount())
+
+	require.NoError(t, traces.Shutdown(context.Background()))
+	require.NoError(t, logs.Shutdown(context.Background()))
+}
+
+// gatedTracesConsumer blocks every export until release is closed.
+type gatedTracesConsumer struct {
+	consumertest.TracesSink
+	release chan struct{}
+}
+
+func (gc *gatedTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	<-gc.release
+	return gc.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestBatchProcessorDropOldestWhenOverloaded(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := Config{
+		Timeout:          3 * time.Second,
+		SendBatchSize:    10,
+		MaxInFlightItems: 20,
+	}
+	sink := &gatedTracesConsumer{release: make(chan struct{})}
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 100
+	spansPerRequest := 10
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		td := testdata.GenerateTraceDataManySpansSameResource(spansPerRequest)
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+		assert.LessOrEqual(t, atomic.LoadInt64(&batcher.inFlightItems), int64(cfg.MaxInFlightItems))
+	}
+
+	close(sink.release)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	viewData, err := view.RetrieveData("processor/batch/" + statDroppedItems.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	dropped := int(viewData[0].Data.(*view.SumData).Value)
+	assert.Greater(t, dropped, 0)
+	assert.Equal(t, requestCount*spansPerRequest, sink.SpansCount()+dropped)
+	assert.Equal(t, int64(0), atomic.LoadInt64(&batcher.inFlightItems))
+}
+
+func TestBatchProcessorSpanCountUnevenSplits(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 128
+	cfg.SendBatchMaxSize = 128
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// None of these divide evenly by the max size.
+	spansPerRequest := []int{7, 13, 150, 257, 3, 1000, 129, 127}
+	totalSpans := 0
+	for _, spans := range spansPerRequest {
+		totalSpans += spans
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(spans)))
+	}
+
+	// wait for all spans to be reported
+	for {
+		if sink.SpansCount() == totalSpans {
+			break
+		}
+		<-time.After(cfg.Timeout)
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, totalSpans, sink.SpansCount())
+	for _, td := range sink.AllTraces() {
+		assert.LessOrEqual(t, td.SpanCount(), int(cfg.SendBatchMaxSize))
+	}
+
+	// The recorded batch sizes are derived from the tracked span count, so any
+	// drift from the exported data would show up here.
+	viewData, err := view.RetrieveData("processor/batch/" + statBatchSendSize.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	distData := viewData[0].Data.(*view.DistributionData)
+	assert.Equal(t, int64(len(sink.AllTraces())), distData.Count)
+	assert.Equal(t, totalSpans, int(distData.Sum()))
+	assert.LessOrEqual(t, distData.Max, float64(cfg.SendBatchMaxSize))
+}
+
+func TestBatchMetricProcessorWithMaxSize(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.SendBatchMaxSize = 10
+	cfg.SplitMetrics = true
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchMetricsProcessor(creationParams, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := testdata.GenerateMetricsManyMetricsSameResource(25)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metrics.At(i).IntSum().DataPoints().Resize(50)
+	}
+	require.NoError(t, batcher.ConsumeMetrics(context.Background(), md))
+
+	// wait for all metrics to be reported
+	for sink.MetricsCount() < 25 {
+		<-time.After(10 * time.Millisecond)
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, 25, sink.MetricsCount())
+	for _, md := range sink.AllMetrics() {
+		metricCount, dataPointCount := md.MetricAndDataPointCount()
+		assert.LessOrEqual(t, metricCount, int(cfg.SendBatchMaxSize))
+		// No metric lost data points to another batch.
+		assert.Equal(t, 50*metricCount, dataPointCount)
+	}
+}
+
+func TestBatchMetricProcessorWithMaxSizeWithoutSplitMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.SendBatchMaxSize = 10
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchMetricsProcessor(creationParams, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeMetrics(context.Background(), testdata.GenerateMetricsManyMetricsSameResource(25)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	// send_batch_max_size alone does not split metric batches.
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 25, sink.MetricsCount())
+}
+
+func TestBatchProcessorKeepTracesTogether(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.SendBatchMaxSize = 10
+	cfg.KeepTracesTogether = true
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// 7 traces of 4 spans do not fit evenly in batches of 10 spans.
+	td := generateInterleavedTraces(7, 4)
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+
+	// wait for all spans to be reported
+	for sink.SpansCount() < 28 {
+		<-time.After(10 * time.Millisecond)
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, 28, sink.SpansCount())
+	seen := make(map[[16]byte]bool)
+	for _, batch := range sink.AllTraces() {
+		for id, count := range spanCountsByTraceID(batch) {
+			assert.Equal(t, 4, count, "trace split across batches")
+			assert.False(t, seen[id], "trace exported in more than one batch")
+			seen[id] = true
+		}
+	}
+	assert.Len(t, seen, 7)
+}
+
+func TestBatchProcessorDropOldestConcurrentProducers(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := Config{
+		Timeout:          3 * time.Second,
+		SendBatchSize:    10,
+		MaxInFlightItems: 20,
+	}
+	sink := &gatedTracesConsumer{release: make(chan struct{})}
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	producers := 8
+	requestCount := 50
+	spansPerRequest := 3
+	var overCap int32
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for requestNum := 0; requestNum < requestCount; requestNum++ {
+				td := testdata.GenerateTraceDataManySpansSameResource(spansPerRequest)
+				assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+				if atomic.LoadInt64(&batcher.inFlightItems) > int64(cfg.MaxInFlightItems) {
+					atomic.StoreInt32(&overCap, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(0), atomic.LoadInt32(&overCap))
+	assert.LessOrEqual(t, atomic.LoadInt64(&batcher.inFlightItems), int64(cfg.MaxInFlightItems))
+
+	close(sink.release)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	viewData, err := view.RetrieveData("processor/batch/" + statDroppedItems.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	dropped := int(viewData[0].Data.(*view.SumData).Value)
+	assert.Greater(t, dropped, 0)
+	assert.Equal(t, producers*requestCount*spansPerRequest, sink.SpansCount()+dropped)
+}
+
+func TestBatchProcessorDropOversizedItem(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := Config{
+		Timeout:          3 * time.Second,
+		SendBatchSize:    10,
+		MaxInFlightItems: 20,
+	}
+	sink := new(consumertest.TracesSink)
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(1000)))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&batcher.inFlightItems))
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(5)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, 5, sink.SpansCount())
+	viewData, err := view.RetrieveData("processor/batch/" + statDroppedItems.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.Equal(t, float64(1000), viewData[0].Data.(*view.SumData).Value)
+}
@@ -15,6 +15,8 @@
 package batchprocessor
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -33,4 +35,113 @@ type Config struct {
 	// SendBatchMaxSize is the maximum size of a batch. Larger batches are split into smaller units.
 	// Default value is 0, that means no maximum size.
 	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size,omitempty"`
+
+	// KeepTracesTogether splits traces larger than SendBatchMaxSize on trace boundaries, so
+	// that all the spans of a trace are exported in the same batch. A batch can then be larger
+	// than SendBatchMaxSize when a single trace does not fit in it.
+	KeepTracesTogether bool `mapstructure:"keep_traces_together,omitempty"`
+
+	// SplitMetrics also splits the metric batches larger than SendBatchMaxSize. Whole metrics are
+	// moved between batches, so the data points of a metric are always exported together.
+	SplitMetrics bool `mapstructure:"split_metrics,omitempty"`
+
+	// ExportTimeout bounds the time spent sending a single batch to the next consumer.
+	// Default value is 0, that means no timeout.
+	ExportTimeout time.Duration `mapstructure:"export_timeout,omitempty"`
+
+	// MaxInFlightItems is the maximum number of items buffered by the processor. When exceeded
+	// the oldest buffered items are dropped instead of blocking the caller.
+	// Default value is 0, that means callers are blocked until the processor can accept more items.
+	MaxInFlightItems uint32 `mapstructure:"max_in_flight_items,omitempty"`
+
+	// RequestCountAttribute is the key of a resource attribute set on every resource of an
+	// exported batch to the number of requests merged into the batch.
+	// Default value is empty, that means no attribute is set.
+	RequestCountAttribute string `mapstructure:"request_count_attribute,omitempty"`
+
+	// Traces overrides Timeout and SendBatchSize for the traces pipeline.
+	Traces *SignalSettings `mapstructure:"traces"`
+
+	// Metrics overrides Timeout and SendBatchSize for the metrics pipeline.
+	Metrics *SignalSettings `mapstructure:"metrics"`
+
+	// Logs overrides Timeout and SendBatchSize for the logs pipeline.
+	Logs *SignalSettings `mapstructure:"logs"`
+}
+
+// SignalSettings defines the batching settings that can be overridden for a single signal.
+// Zero values inherit the corresponding setting from Config.
+type SignalSettings struct {
+	// Timeout sets the time after which a batch of this signal will be sent regardless of size.
+	Timeout time.Duration `mapstructure:"timeout,omitempty"`
+
+	// SendBatchSize is the size of a batch of this signal which after hit, will trigger it to be sent.
+	SendBatchSize uint32 `mapstructure:"send_batch_size,omitempty"`
+}
+
+var (
+	errNonPositiveTimeout       = errors.New("timeout must be positive")
+	errMaxSizeTooSmall          = errors.New("send_batch_max_size must be greater or equal to send_batch_size")
+	errNegativeSignalTimeout    = errors.New("signal timeout override must not be negative")
+	errNegativeExportTimeout    = errors.New("export_timeout must not be negative")
+	errMaxInFlightItemsTooSmall = errors.New("max_in_flight_items must be larger than send_batch_size")
+	errSignalBatchSizeTooLarge  = errors.New("signal send_batch_size override must not be larger than send_batch_max_size")
+	errInvalidRequestCountAttr  = errors.New("request_count_attribute must not be blank or start or end with spaces")
+	errKeepTracesWithoutMaxSize = errors.New("keep_traces_together requires send_batch_max_size to be set")
+	errSplitMetricsWithoutMax   = errors.New("split_metrics requires send_batch_max_size to be set")
+)
+
+// Validate checks that the configuration settings are consistent with each other.
+func (cfg *Config) Validate() error {
+	if cfg.Timeout <= 0 {
+		return errNonPositiveTimeout
+	}
+	if cfg.SendBatchMaxSize > 0 && cfg.SendBatchMaxSize < cfg.SendBatchSize {
+		return errMaxSizeTooSmall
+	}
+	if cfg.KeepTracesTogether && cfg.SendBatchMaxSize == 0 {
+		return errKeepTracesWithoutMaxSize
+	}
+	if cfg.SplitMetrics && cfg.SendBatchMaxSize == 0 {
+		return errSplitMetricsWithoutMax
+	}
+	if cfg.ExportTimeout < 0 {
+		return errNegativeExportTimeout
+	}
+	if cfg.RequestCountAttribute != "" && strings.TrimSpace(cfg.RequestCountAttribute) != cfg.RequestCountAttribute {
+		return errInvalidRequestCountAttr
+	}
+	for _, s := range []*SignalSettings{cfg.Traces, cfg.Metrics, cfg.Logs} {
+		if s == nil {
+			continue
+		}
+		if s.Timeout < 0 {
+			return errNegativeSignalTimeout
+		}
+		if cfg.SendBatchMaxSize > 0 && s.SendBatchSize > cfg.SendBatchMaxSize {
+			return errSignalBatchSizeTooLarge
+		}
+		if cfg.MaxInFlightItems > 0 && cfg.MaxInFlightItems <= s.SendBatchSize {
+			return errMaxInFlightItemsTooSmall
+		}
+	}
+	if cfg.MaxInFlightItems > 0 && cfg.MaxInFlightItems <= cfg.SendBatchSize {
+		return errMaxInFlightItemsTooSmall
+	}
+	return nil
+}
+
+// withSignalSettings returns a copy of the config with the given signal overrides applied.
+func (cfg *Config) withSignalSettings(s *SignalSettings) *Config {
+	out := *cfg
+	if s == nil {
+		return &out
+	}
+	if s.Timeout > 0 {
+		out.Timeout = s.Timeout
+	}
+	if s.SendBatchSize > 0 {
+		out.SendBatchSize = s.SendBatchSize
+	}
+	return &out
 }
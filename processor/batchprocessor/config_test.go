@@ -57,4 +57,140 @@ func TestLoadConfig(t *testing.T) {
 			SendBatchMaxSize: sendBatchMaxSize,
 			Timeout:          timeout,
 		})
+
+	p2 := cfg.Processors["batch/3"]
+	assert.Equal(t, p2,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "batch",
+				NameVal: "batch/3",
+			},
+			Timeout:       timeout,
+			SendBatchSize: defaultSendBatchSize,
+			Traces: &SignalSettings{
+				Timeout: time.Second,
+			},
+			Logs: &SignalSettings{
+				SendBatchSize: 1000,
+			},
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr error
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:    "zero timeout",
+			modify:  func(cfg *Config) { cfg.Timeout = 0 },
+			wantErr: errNonPositiveTimeout,
+		},
+		{
+			name:    "negative timeout",
+			modify:  func(cfg *Config) { cfg.Timeout = -time.Second },
+			wantErr: errNonPositiveTimeout,
+		},
+		{
+			name:    "max size smaller than batch size",
+			modify:  func(cfg *Config) { cfg.SendBatchMaxSize = cfg.SendBatchSize - 1 },
+			wantErr: errMaxSizeTooSmall,
+		},
+		{
+			name:   "max size equal to batch size",
+			modify: func(cfg *Config) { cfg.SendBatchMaxSize = cfg.SendBatchSize },
+		},
+		{
+			name:    "keep traces together without max size",
+			modify:  func(cfg *Config) { cfg.KeepTracesTogether = true },
+			wantErr: errKeepTracesWithoutMaxSize,
+		},
+		{
+			name:    "split metrics without max size",
+			modify:  func(cfg *Config) { cfg.SplitMetrics = true },
+			wantErr: errSplitMetricsWithoutMax,
+		},
+		{
+			name: "split metrics with max size",
+			modify: func(cfg *Config) {
+				cfg.SendBatchMaxSize = cfg.SendBatchSize
+				cfg.SplitMetrics = true
+			},
+		},
+		{
+			name: "keep traces together with max size",
+			modify: func(cfg *Config) {
+				cfg.SendBatchMaxSize = cfg.SendBatchSize
+				cfg.KeepTracesTogether = true
+			},
+		},
+		{
+			name:   "request count attribute",
+			modify: func(cfg *Config) { cfg.RequestCountAttribute = "batch.request_count" },
+		},
+		{
+			name:    "blank request count attribute",
+			modify:  func(cfg *Config) { cfg.RequestCountAttribute = " " },
+			wantErr: errInvalidRequestCountAttr,
+		},
+		{
+			name:    "request count attribute with spaces",
+			modify:  func(cfg *Config) { cfg.RequestCountAttribute = "batch.request_count " },
+			wantErr: errInvalidRequestCountAttr,
+		},
+		{
+			name:    "negative export timeout",
+			modify:  func(cfg *Config) { cfg.ExportTimeout = -time.Second },
+			wantErr: errNegativeExportTimeout,
+		},
+		{
+			name:    "negative signal timeout",
+			modify:  func(cfg *Config) { cfg.Logs = &SignalSettings{Timeout: -time.Second} },
+			wantErr: errNegativeSignalTimeout,
+		},
+		{
+			name: "signal batch size larger than max size",
+			modify: func(cfg *Config) {
+				cfg.SendBatchMaxSize = cfg.SendBatchSize
+				cfg.Metrics = &SignalSettings{SendBatchSize: cfg.SendBatchMaxSize + 1}
+			},
+			wantErr: errSignalBatchSizeTooLarge,
+		},
+		{
+			name: "signal batch size equal to max size",
+			modify: func(cfg *Config) {
+				cfg.SendBatchMaxSize = cfg.SendBatchSize
+				cfg.Metrics = &SignalSettings{SendBatchSize: cfg.SendBatchMaxSize}
+			},
+		},
+		{
+			name:    "max in flight items equal to batch size",
+			modify:  func(cfg *Config) { cfg.MaxInFlightItems = cfg.SendBatchSize },
+			wantErr: errMaxInFlightItemsTooSmall,
+		},
+		{
+			name:   "max in flight items larger than batch size",
+			modify: func(cfg *Config) { cfg.MaxInFlightItems = cfg.SendBatchSize + 1 },
+		},
+		{
+			name: "max in flight items equal to signal batch size",
+			modify: func(cfg *Config) {
+				cfg.MaxInFlightItems = cfg.SendBatchSize + 1
+				cfg.Traces = &SignalSettings{SendBatchSize: cfg.MaxInFlightItems}
+			},
+			wantErr: errMaxInFlightItemsTooSmall,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.Equal(t, tt.wantErr, cfg.Validate())
+		})
+	}
 }
@@ -61,6 +61,9 @@ func createTraceProcessor(
 	nextConsumer consumer.TracesConsumer,
 ) (component.TracesProcessor, error) {
 	oCfg := cfg.(*Config)
+	if err := oCfg.Validate(); err != nil {
+		return nil, err
+	}
 	level := configtelemetry.GetMetricsLevelFlagValue()
 	return newBatchTracesProcessor(params, nextConsumer, oCfg, level), nil
 }
@@ -72,6 +75,9 @@ func createMetricsProcessor(
 	nextConsumer consumer.MetricsConsumer,
 ) (component.MetricsProcessor, error) {
 	oCfg := cfg.(*Config)
+	if err := oCfg.Validate(); err != nil {
+		return nil, err
+	}
 	level := configtelemetry.GetMetricsLevelFlagValue()
 	return newBatchMetricsProcessor(params, nextConsumer, oCfg, level), nil
 }
@@ -83,6 +89,9 @@ func createLogsProcessor(
 	nextConsumer consumer.LogsConsumer,
 ) (component.LogsProcessor, error) {
 	oCfg := cfg.(*Config)
+	if err := oCfg.Validate(); err != nil {
+		return nil, err
+	}
 	level := configtelemetry.GetMetricsLevelFlagValue()
 	return newBatchLogsProcessor(params, nextConsumer, oCfg, level), nil
 }
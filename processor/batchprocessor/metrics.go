@@ -28,10 +28,20 @@ var (
 	statTimeoutTriggerSend   = stats.Int64("timeout_trigger_send", "Number of times the batch was sent due to a timeout trigger", stats.UnitDimensionless)
 	statBatchSendSize        = stats.Int64("batch_send_size", "Number of units in the batch", stats.UnitDimensionless)
 	statBatchSendSizeBytes   = stats.Int64("batch_send_size_bytes", "Number of bytes in batch that was sent", stats.UnitBytes)
+	statExportTimeout        = stats.Int64("batch_export_timeout", "Number of times a batch export exceeded its deadline", stats.UnitDimensionless)
+	statDroppedItems         = stats.Int64("batch_dropped_items", "Number of items dropped because max_in_flight_items was exceeded", stats.UnitDimensionless)
 )
 
 // MetricViews returns the metrics views related to batching
 func MetricViews() []*view.View {
+	return obsreport.ProcessorMetricViews(typeStr, LegacyMetricViews())
+}
+
+// LegacyMetricViews returns the metrics views related to batching under their
+// legacy names, without the "processor/batch/" prefix. Registered next to the
+// views returned by MetricViews, the same measurements are published under both
+// names, e.g. to compare them while migrating dashboards.
+func LegacyMetricViews() []*view.View {
 	processorTagKeys := []tag.Key{processor.TagProcessorNameKey}
 
 	countBatchSizeTriggerSendView := &view.View{
@@ -68,12 +78,28 @@ func MetricViews() []*view.View {
 			1000_000, 2000_000, 3000_000, 4000_000, 5000_000, 6000_000, 7000_000, 8000_000, 9000_000),
 	}
 
-	legacyViews := []*view.View{
+	countExportTimeoutView := &view.View{
+		Name:        statExportTimeout.Name(),
+		Measure:     statExportTimeout,
+		Description: statExportTimeout.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countDroppedItemsView := &view.View{
+		Name:        statDroppedItems.Name(),
+		Measure:     statDroppedItems,
+		Description: statDroppedItems.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{
 		countBatchSizeTriggerSendView,
 		countTimeoutTriggerSendView,
 		distributionBatchSendSizeView,
 		distributionBatchSendSizeBytesView,
+		countExportTimeoutView,
+		countDroppedItemsView,
 	}
-
-	return obsreport.ProcessorMetricViews(typeStr, legacyViews)
 }
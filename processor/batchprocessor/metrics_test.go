@@ -15,9 +15,19 @@
 package batchprocessor
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
 )
 
 func TestBatchProcessorMetrics(t *testing.T) {
@@ -26,9 +36,48 @@ func TestBatchProcessorMetrics(t *testing.T) {
 		"timeout_trigger_send",
 		"batch_send_size",
 		"batch_send_size_bytes",
+		"batch_export_timeout",
+		"batch_dropped_items",
 	}
 	views := MetricViews()
 	for i, viewName := range viewNames {
 		assert.Equal(t, "processor/batch/"+viewName, views[i].Name)
 	}
 }
+
+func TestBatchProcessorLegacyMetrics(t *testing.T) {
+	views := MetricViews()
+	legacyViews := LegacyMetricViews()
+	require.Equal(t, len(views), len(legacyViews))
+	for i, legacyView := range legacyViews {
+		assert.Equal(t, legacyView.Measure.Name(), legacyView.Name)
+		assert.Equal(t, "processor/batch/"+legacyView.Name, views[i].Name)
+	}
+
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+	require.NoError(t, view.Register(legacyViews...))
+	defer view.Unregister(legacyViews...)
+
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	for i := 0; i < 7; i++ {
+		require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(5)))
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	require.Equal(t, 35, sink.SpansCount())
+
+	for _, name := range []string{statBatchSizeTriggerSend.Name(), statBatchSendSize.Name(), statBatchSendSizeBytes.Name()} {
+		viewData, err := view.RetrieveData("processor/batch/" + name)
+		require.NoError(t, err)
+		require.Len(t, viewData, 1)
+		legacyViewData, err := view.RetrieveData(name)
+		require.NoError(t, err)
+		require.Len(t, legacyViewData, 1)
+		assert.Equal(t, viewData[0].Data, legacyViewData[0].Data, name)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+// splitLevels gives splitItems access to the resource and instrumentation
+// library levels of the data being split, and builds the split off data.
+type splitLevels interface {
+	resourceLen() int
+	libraryLen(resource int) int
+	itemLen(resource, library int) int
+
+	// appendResource appends a copy of the resource, without its libraries, to the result.
+	appendResource(resource int)
+	// appendLibrary appends a copy of the library, without its items, to the last
+	// resource of the result.
+	appendLibrary(resource, library int)
+	// moveLastItems moves the last n items of the library to the last library of the result.
+	moveLastItems(resource, library, n int)
+
+	removeLastLibrary(resource int)
+	removeLastResource()
+}
+
+// splitItems moves size items from the end of the data to the result, removing the
+// libraries and resources left empty.
+func splitItems(size int, s splitLevels) {
+	copied := 0
+	for i := s.resourceLen() - 1; i >= 0; i-- {
+		s.appendResource(i)
+		for j := s.libraryLen(i) - 1; j >= 0; j-- {
+			s.appendLibrary(i, j)
+			n := s.itemLen(i, j)
+			if n > size-copied {
+				n = size - copied
+			}
+			s.moveLastItems(i, j, n)
+			copied += n
+			if s.itemLen(i, j) == 0 {
+				s.removeLastLibrary(i)
+			}
+			if copied == size {
+				return
+			}
+		}
+		if s.libraryLen(i) == 0 {
+			s.removeLastResource()
+		}
+	}
+}
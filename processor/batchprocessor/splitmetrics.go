@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// splitMetrics removes metrics from the input data and returns a new data of the specified size.
+// Whole metrics are split off, so the data points of a metric are never split between the two.
+func splitMetrics(size int, toSplit pdata.Metrics) pdata.Metrics {
+	if toSplit.MetricCount() <= size {
+		return toSplit
+	}
+	s := &metricsSplitLevels{src: toSplit.ResourceMetrics(), result: pdata.NewMetrics()}
+	splitItems(size, s)
+	return s.result
+}
+
+// metricsSplitLevels walks the metrics for splitItems, a metric is moved with all
+// its data points.
+type metricsSplitLevels struct {
+	src     pdata.ResourceMetricsSlice
+	result  pdata.Metrics
+	destRm  pdata.ResourceMetrics
+	destIlm pdata.InstrumentationLibraryMetrics
+}
+
+func (s *metricsSplitLevels) resourceLen() int {
+	return s.src.Len()
+}
+
+func (s *metricsSplitLevels) libraryLen(resource int) int {
+	return s.src.At(resource).InstrumentationLibraryMetrics().Len()
+}
+
+func (s *metricsSplitLevels) itemLen(resource, library int) int {
+	return s.src.At(resource).InstrumentationLibraryMetrics().At(library).Metrics().Len()
+}
+
+func (s *metricsSplitLevels) appendResource(resource int) {
+	s.destRm = pdata.NewResourceMetrics()
+	s.src.At(resource).Resource().CopyTo(s.destRm.Resource())
+	s.result.ResourceMetrics().Append(s.destRm)
+}
+
+func (s *metricsSplitLevels) appendLibrary(resource, library int) {
+	s.destIlm = pdata.NewInstrumentationLibraryMetrics()
+	s.destRm.InstrumentationLibraryMetrics().Append(s.destIlm)
+	s.src.At(resource).InstrumentationLibraryMetrics().At(library).InstrumentationLibrary().CopyTo(s.destIlm.InstrumentationLibrary())
+}
+
+func (s *metricsSplitLevels) moveLastItems(resource, library, n int) {
+	metrics := s.src.At(resource).InstrumentationLibraryMetrics().At(library).Metrics()
+	s.destIlm.Metrics().Resize(n)
+	for k := 0; k < n; k++ {
+		metrics.At(metrics.Len() - 1 - k).CopyTo(s.destIlm.Metrics().At(k))
+	}
+	metrics.Resize(metrics.Len() - n)
+}
+
+func (s *metricsSplitLevels) removeLastLibrary(resource int) {
+	ilms := s.src.At(resource).InstrumentationLibraryMetrics()
+	ilms.Resize(ilms.Len() - 1)
+}
+
+func (s *metricsSplitLevels) removeLastResource() {
+	s.src.Resize(s.src.Len() - 1)
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestSplitMetrics_noop(t *testing.T) {
+	md := testdata.GenerateMetricsManyMetricsSameResource(20)
+	split := splitMetrics(40, md)
+	assert.Equal(t, md, split)
+}
+
+func TestSplitMetrics(t *testing.T) {
+	md := testdata.GenerateMetricsManyMetricsSameResource(20)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metrics.At(i).SetName("test-metric-" + strconv.Itoa(i))
+	}
+	// A metric with many data points is kept whole.
+	metrics.At(17).IntSum().DataPoints().Resize(100)
+
+	split := splitMetrics(5, md)
+	assert.Equal(t, 5, split.MetricCount())
+	assert.Equal(t, 15, md.MetricCount())
+	got := split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t, "test-metric-19", got.At(0).Name())
+	assert.Equal(t, "test-metric-15", got.At(4).Name())
+	assert.Equal(t, "test-metric-17", got.At(2).Name())
+	assert.Equal(t, 100, got.At(2).IntSum().DataPoints().Len())
+	_, dataPoints := md.MetricAndDataPointCount()
+	assert.Equal(t, 15*2, dataPoints)
+}
+
+func TestSplitMetricsMultipleResourceMetrics(t *testing.T) {
+	md := testdata.GenerateMetricsManyMetricsSameResource(20)
+	md.ResourceMetrics().Resize(2)
+	testdata.GenerateMetricsManyMetricsSameResource(20).ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().At(1))
+
+	split := splitMetrics(25, md)
+	assert.Equal(t, 25, split.MetricCount())
+	assert.Equal(t, 15, md.MetricCount())
+	assert.Equal(t, 2, split.ResourceMetrics().Len())
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+}
@@ -23,43 +23,142 @@ func splitTrace(size int, toSplit pdata.Traces) pdata.Traces {
 	if toSplit.SpanCount() <= size {
 		return toSplit
 	}
-	copiedSpans := 0
-	result := pdata.NewTraces()
+	s := &tracesSplitLevels{src: toSplit.ResourceSpans(), result: pdata.NewTraces()}
+	splitItems(size, s)
+	return s.result
+}
+
+// tracesSplitLevels walks the spans for splitItems.
+type tracesSplitLevels struct {
+	src     pdata.ResourceSpansSlice
+	result  pdata.Traces
+	destRs  pdata.ResourceSpans
+	destIls pdata.InstrumentationLibrarySpans
+}
+
+func (s *tracesSplitLevels) resourceLen() int {
+	return s.src.Len()
+}
+
+func (s *tracesSplitLevels) libraryLen(resource int) int {
+	return s.src.At(resource).InstrumentationLibrarySpans().Len()
+}
+
+func (s *tracesSplitLevels) itemLen(resource, library int) int {
+	return s.src.At(resource).InstrumentationLibrarySpans().At(library).Spans().Len()
+}
+
+func (s *tracesSplitLevels) appendResource(resource int) {
+	s.destRs = pdata.NewResourceSpans()
+	s.src.At(resource).Resource().CopyTo(s.destRs.Resource())
+	s.result.ResourceSpans().Append(s.destRs)
+}
+
+func (s *tracesSplitLevels) appendLibrary(resource, library int) {
+	s.destIls = pdata.NewInstrumentationLibrarySpans()
+	s.destRs.InstrumentationLibrarySpans().Append(s.destIls)
+	s.src.At(resource).InstrumentationLibrarySpans().At(library).InstrumentationLibrary().CopyTo(s.destIls.InstrumentationLibrary())
+}
+
+func (s *tracesSplitLevels) moveLastItems(resource, library, n int) {
+	spans := s.src.At(resource).InstrumentationLibrarySpans().At(library).Spans()
+	s.destIls.Spans().Resize(n)
+	for k := 0; k < n; k++ {
+		spans.At(spans.Len() - 1 - k).CopyTo(s.destIls.Spans().At(k))
+	}
+	spans.Resize(spans.Len() - n)
+}
+
+func (s *tracesSplitLevels) removeLastLibrary(resource int) {
+	ilss := s.src.At(resource).InstrumentationLibrarySpans()
+	ilss.Resize(ilss.Len() - 1)
+}
+
+func (s *tracesSplitLevels) removeLastResource() {
+	s.src.Resize(s.src.Len() - 1)
+}
+
+// splitTraceByTraceID removes whole traces from the input trace and returns them in a
+// new trace of at most the specified size. The spans of a trace are never separated,
+// so the result is larger than size when the first trace alone does not fit.
+func splitTraceByTraceID(size int, toSplit pdata.Traces) pdata.Traces {
+	if toSplit.SpanCount() <= size {
+		return toSplit
+	}
+
+	// Count the spans of every trace, walking the spans in the same order as splitTrace.
+	spanCounts := make(map[[16]byte]int)
+	var order [][16]byte
 	rss := toSplit.ResourceSpans()
 	for i := rss.Len() - 1; i >= 0; i-- {
-		rs := rss.At(i)
-		destRs := pdata.NewResourceSpans()
-		rs.Resource().CopyTo(destRs.Resource())
-		result.ResourceSpans().Append(destRs)
-
-		for j := rs.InstrumentationLibrarySpans().Len() - 1; j >= 0; j-- {
-			instSpans := rs.InstrumentationLibrarySpans().At(j)
-			destInstSpans := pdata.NewInstrumentationLibrarySpans()
-			destRs.InstrumentationLibrarySpans().Append(destInstSpans)
-			instSpans.InstrumentationLibrary().CopyTo(destInstSpans.InstrumentationLibrary())
-
-			if size-copiedSpans >= instSpans.Spans().Len() {
-				destInstSpans.Spans().Resize(instSpans.Spans().Len())
-			} else {
-				destInstSpans.Spans().Resize(size - copiedSpans)
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := ilss.Len() - 1; j >= 0; j-- {
+			spans := ilss.At(j).Spans()
+			for k := spans.Len() - 1; k >= 0; k-- {
+				id := spans.At(k).TraceID().Bytes()
+				if spanCounts[id] == 0 {
+					order = append(order, id)
+				}
+				spanCounts[id]++
 			}
-			for k, destIdx := instSpans.Spans().Len()-1, 0; k >= 0 && copiedSpans < size; k, destIdx = k-1, destIdx+1 {
-				span := instSpans.Spans().At(k)
-				span.CopyTo(destInstSpans.Spans().At(destIdx))
-				copiedSpans++
-				// remove span
-				instSpans.Spans().Resize(instSpans.Spans().Len() - 1)
-			}
-			if instSpans.Spans().Len() == 0 {
-				rs.InstrumentationLibrarySpans().Resize(rs.InstrumentationLibrarySpans().Len() - 1)
+		}
+	}
+
+	selected := make(map[[16]byte]bool)
+	selectedSpans := 0
+	for _, id := range order {
+		if selectedSpans > 0 && selectedSpans+spanCounts[id] > size {
+			continue
+		}
+		selected[id] = true
+		selectedSpans += spanCounts[id]
+	}
+
+	result := pdata.NewTraces()
+	keptRss := pdata.NewResourceSpansSlice()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		var destRs pdata.ResourceSpans
+		hasDestRs := false
+		keptIlss := pdata.NewInstrumentationLibrarySpansSlice()
+		for j := 0; j < rs.InstrumentationLibrarySpans().Len(); j++ {
+			ils := rs.InstrumentationLibrarySpans().At(j)
+			var destIls pdata.InstrumentationLibrarySpans
+			hasDestIls := false
+			keptSpans := pdata.NewSpanSlice()
+			for k := 0; k < ils.Spans().Len(); k++ {
+				span := ils.Spans().At(k)
+				if !selected[span.TraceID().Bytes()] {
+					keptSpans.Append(span)
+					continue
+				}
+				if !hasDestIls {
+					if !hasDestRs {
+						destRs = pdata.NewResourceSpans()
+						rs.Resource().CopyTo(destRs.Resource())
+						result.ResourceSpans().Append(destRs)
+						hasDestRs = true
+					}
+					destIls = pdata.NewInstrumentationLibrarySpans()
+					ils.InstrumentationLibrary().CopyTo(destIls.InstrumentationLibrary())
+					destRs.InstrumentationLibrarySpans().Append(destIls)
+					hasDestIls = true
+				}
+				destIls.Spans().Append(span)
 			}
-			if copiedSpans == size {
-				return result
+			if keptSpans.Len() > 0 {
+				ils.Spans().Resize(0)
+				keptSpans.MoveAndAppendTo(ils.Spans())
+				keptIlss.Append(ils)
 			}
 		}
-		if rs.InstrumentationLibrarySpans().Len() == 0 {
-			rss.Resize(rss.Len() - 1)
+		if keptIlss.Len() > 0 {
+			rs.InstrumentationLibrarySpans().Resize(0)
+			keptIlss.MoveAndAppendTo(rs.InstrumentationLibrarySpans())
+			keptRss.Append(rs)
 		}
 	}
+	rss.Resize(0)
+	keptRss.MoveAndAppendTo(rss)
 	return result
 }
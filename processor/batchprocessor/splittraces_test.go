@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/testdata"
@@ -111,3 +112,81 @@ func TestSplitTracesMultipleResourceSpans_split_size_greater_than_span_size(t *t
 	assert.Equal(t, "test-span-0-19", split.ResourceSpans().At(1).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
 	assert.Equal(t, "test-span-0-15", split.ResourceSpans().At(1).InstrumentationLibrarySpans().At(0).Spans().At(4).Name())
 }
+
+// generateInterleavedTraces returns numTraces traces of spansPerTrace spans each,
+// with the spans of the traces interleaved across two resources.
+func generateInterleavedTraces(numTraces, spansPerTrace int) pdata.Traces {
+	td := testdata.GenerateTraceDataManySpansSameResource(numTraces * spansPerTrace)
+	td.ResourceSpans().Resize(2)
+	testdata.GenerateTraceDataManySpansSameResource(0).ResourceSpans().At(0).CopyTo(td.ResourceSpans().At(1))
+	src := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	moved := pdata.NewSpanSlice()
+	kept := pdata.NewSpanSlice()
+	for i := 0; i < src.Len(); i++ {
+		span := src.At(i)
+		span.SetTraceID(pdata.NewTraceID([16]byte{byte(i%numTraces + 1)}))
+		if i%2 == 0 {
+			kept.Append(span)
+		} else {
+			moved.Append(span)
+		}
+	}
+	src.Resize(0)
+	kept.MoveAndAppendTo(src)
+	moved.MoveAndAppendTo(td.ResourceSpans().At(1).InstrumentationLibrarySpans().At(0).Spans())
+	return td
+}
+
+func spanCountsByTraceID(td pdata.Traces) map[[16]byte]int {
+	counts := make(map[[16]byte]int)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				counts[spans.At(k).TraceID().Bytes()]++
+			}
+		}
+	}
+	return counts
+}
+
+func TestSplitTracesByTraceID(t *testing.T) {
+	td := generateInterleavedTraces(5, 4)
+	require.Equal(t, 20, td.SpanCount())
+
+	split := splitTraceByTraceID(10, td)
+	assert.Equal(t, 8, split.SpanCount())
+	assert.Equal(t, 12, td.SpanCount())
+	splitCounts := spanCountsByTraceID(split)
+	remainCounts := spanCountsByTraceID(td)
+	assert.Len(t, splitCounts, 2)
+	assert.Len(t, remainCounts, 3)
+	for id, count := range splitCounts {
+		assert.Equal(t, 4, count)
+		assert.NotContains(t, remainCounts, id)
+	}
+	// Both resources hold spans of the traces, so both are kept on each side.
+	assert.Equal(t, 2, split.ResourceSpans().Len())
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+}
+
+func TestSplitTracesByTraceID_traceLargerThanSize(t *testing.T) {
+	td := generateInterleavedTraces(2, 6)
+
+	split := splitTraceByTraceID(4, td)
+	assert.Equal(t, 6, split.SpanCount())
+	assert.Len(t, spanCountsByTraceID(split), 1)
+
+	split = splitTraceByTraceID(4, td)
+	assert.Equal(t, 6, split.SpanCount())
+	assert.Equal(t, 0, td.SpanCount())
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+}
+
+func TestSplitTracesByTraceID_noop(t *testing.T) {
+	td := generateInterleavedTraces(2, 2)
+	split := splitTraceByTraceID(4, td)
+	assert.Equal(t, td, split)
+}
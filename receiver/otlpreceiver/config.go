@@ -31,4 +31,9 @@ type Config struct {
 
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// MaxConcurrentRequests is the maximum number of requests handled at the same time
+	// by the gRPC and HTTP servers together. Requests above the limit are rejected.
+	// By default (0) there is no limit.
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
 }
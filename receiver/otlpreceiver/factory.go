@@ -17,6 +17,7 @@ package otlpreceiver
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -42,6 +43,9 @@ const (
 	defaultGRPCEndpoint = "0.0.0.0:4317"
 	defaultHTTPEndpoint = "0.0.0.0:55681"
 	legacyGRPCEndpoint  = "0.0.0.0:55680"
+
+	defaultHTTPReadHeaderTimeout = 10 * time.Second
+	defaultHTTPIdleTimeout       = time.Minute
 )
 
 func NewFactory() component.ReceiverFactory {
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+var errListenerClosed = errors.New("listener closed")
+
+// boundListener owns a bound socket and hands its connections to the listeners
+// attached to it. Reload attaches the new servers to the socket of an unchanged
+// endpoint instead of binding it again, which would fail while the old servers
+// are still draining.
+type boundListener struct {
+	ln        net.Listener
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// done is closed with err once the socket stops accepting connections.
+	done chan struct{}
+	err  error
+}
+
+func newBoundListener(ln net.Listener) *boundListener {
+	b := &boundListener{
+		ln:     ln,
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go b.acceptLoop()
+	return b
+}
+
+func (b *boundListener) acceptLoop() {
+	for {
+		conn, err := b.ln.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				time.Sleep(5 * time.Millisecond)
+				continue
+			}
+			b.err = err
+			close(b.done)
+			return
+		}
+		select {
+		case b.conns <- conn:
+		case <-b.closed:
+			_ = conn.Close()
+		}
+	}
+}
+
+// attach returns a listener accepting the connections of the socket until
+// either of them is closed. Closing it leaves the socket open.
+func (b *boundListener) attach() net.Listener {
+	return &attachedListener{bound: b, closed: make(chan struct{})}
+}
+
+// Close closes the socket, the attached listeners then fail to accept.
+func (b *boundListener) Close() error {
+	b.closeOnce.Do(func() { close(b.closed) })
+	return b.ln.Close()
+}
+
+type attachedListener struct {
+	bound     *boundListener
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *attachedListener) Accept() (net.Conn, error) {
+	select {
+	case <-l.closed:
+		return nil, errListenerClosed
+	default:
+	}
+	select {
+	case conn := <-l.bound.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, errListenerClosed
+	case <-l.bound.done:
+		return nil, l.bound.err
+	}
+}
+
+func (l *attachedListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *attachedListener) Addr() net.Addr {
+	return l.bound.ln.Addr()
+}
@@ -15,11 +15,15 @@
 package otlpreceiver
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
+	collectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
 	v1 "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"
 	"go.opentelemetry.io/collector/internal/testdata"
 )
@@ -101,3 +105,53 @@ func TestJSONPbUnmarshal(t *testing.T) {
 	otlp := pdata.TracesToOtlp(td)
 	assert.EqualValues(t, &proto, otlp[0])
 }
+
+// roundTripDoubleSummaryJSON encodes a DoubleSummary in an OTLP metrics request
+// with the JSON marshaler of the receiver and decodes it back.
+func roundTripDoubleSummaryJSON(t *testing.T, ds pdata.DoubleSummary) (string, pdata.DoubleSummary) {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(1)
+	metrics.At(0).SetDataType(pdata.MetricDataTypeDoubleSummary)
+	ds.CopyTo(metrics.At(0).DoubleSummary())
+
+	jpb := &JSONPb{EmitDefaults: true, OrigName: true}
+	buf, err := jpb.Marshal(&collectormetrics.ExportMetricsServiceRequest{ResourceMetrics: pdata.MetricsToOtlp(md)})
+	require.NoError(t, err)
+
+	var req collectormetrics.ExportMetricsServiceRequest
+	require.NoError(t, jpb.Unmarshal(buf, &req))
+	got := pdata.MetricsFromOtlp(req.ResourceMetrics)
+	require.Equal(t, 1, got.MetricCount())
+	return string(buf), got.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleSummary()
+}
+
+func TestJSONPbDoubleSummaryNonFinite(t *testing.T) {
+	values := map[string]float64{
+		"NaN":       math.NaN(),
+		"Infinity":  math.Inf(1),
+		"-Infinity": math.Inf(-1),
+	}
+	for name, v := range values {
+		t.Run(name, func(t *testing.T) {
+			ds := pdata.NewDoubleSummary()
+			ds.DataPoints().Resize(1)
+			dp := ds.DataPoints().At(0)
+			dp.SetCount(3)
+			dp.SetSum(v)
+			dp.QuantileValues().Resize(2)
+			dp.QuantileValues().At(0).SetQuantile(0.5)
+			dp.QuantileValues().At(0).SetValue(v)
+			dp.QuantileValues().At(1).SetQuantile(1)
+			dp.QuantileValues().At(1).SetValue(42)
+
+			encoded, got := roundTripDoubleSummaryJSON(t, ds)
+			// Non-finite values are encoded as strings, which plain JSON can represent.
+			assert.True(t, json.Valid([]byte(encoded)), encoded)
+			assert.Contains(t, encoded, `"sum":"`+name+`"`)
+			assert.True(t, ds.Equal(got), encoded)
+		})
+	}
+}
@@ -16,12 +16,14 @@ package otlpreceiver
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"net"
 	"net/http"
 	"sync"
 
 	gatewayruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/soheilhy/cmux"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
@@ -29,15 +31,23 @@ import (
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	collectorlog "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
 	collectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
 	collectortrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
+	"go.opentelemetry.io/collector/internal/middleware"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/trace"
 )
 
+var (
+	errSharedEndpointTLS = errors.New("gRPC and HTTP can only share an endpoint when TLS is disabled for both")
+	errReloadProtocols   = errors.New("cannot reload receiver: the enabled protocols cannot change")
+	errReloadNotRunning  = errors.New("cannot reload receiver: receiver is not running")
+)
+
 // otlpReceiver is the type that exposes Trace and Metrics reception.
 type otlpReceiver struct {
 	cfg        *Config
@@ -49,9 +59,25 @@ type otlpReceiver struct {
 	metricsReceiver *metrics.Receiver
 	logReceiver     *logs.Receiver
 
+	// limiter bounds the concurrent requests of both servers, nil if unlimited.
+	limiter *requestLimiter
+
 	stopOnce        sync.Once
 	startServerOnce sync.Once
 
+	// mu guards the servers and listeners swapped by Reload.
+	mu sync.Mutex
+	// host is set while the receiver is running.
+	host component.Host
+	// stopCh is closed when the current servers are stopped on purpose.
+	stopCh chan struct{}
+	// listeners are the sockets of the current servers by endpoint.
+	listeners map[string]*boundListener
+	// attached are the listeners the current servers accept connections from.
+	attached []net.Listener
+	// cancelDrains stops the drains of the servers replaced by Reload.
+	cancelDrains []context.CancelFunc
+
 	logger *zap.Logger
 }
 
@@ -60,15 +86,15 @@ type otlpReceiver struct {
 // as the various Stop*Reception methods to end it.
 func newOtlpReceiver(cfg *Config, logger *zap.Logger) (*otlpReceiver, error) {
 	r := &otlpReceiver{
-		cfg:    cfg,
-		logger: logger,
+		cfg:     cfg,
+		limiter: newRequestLimiter(cfg.MaxConcurrentRequests),
+		logger:  logger,
 	}
 	if cfg.GRPC != nil {
-		opts, err := cfg.GRPC.ToServerOption()
-		if err != nil {
+		var err error
+		if r.serverGRPC, err = r.newGRPCServer(cfg.GRPC); err != nil {
 			return nil, err
 		}
-		r.serverGRPC = grpc.NewServer(opts...)
 	}
 	if cfg.HTTP != nil {
 		// Use our custom JSON marshaler instead of default Protobuf JSON marshaler.
@@ -80,8 +106,8 @@ func newOtlpReceiver(cfg *Config, logger *zap.Logger) (*otlpReceiver, error) {
 			OrigName:     true,
 		}
 		r.gatewayMux = gatewayruntime.NewServeMux(
-			gatewayruntime.WithProtoErrorHandler(gatewayruntime.DefaultHTTPProtoErrorHandler),
-			gatewayruntime.WithMarshalerOption("application/x-protobuf", &xProtobufMarshaler{}),
+			gatewayruntime.WithProtoErrorHandler(protoErrorHandler),
+			gatewayruntime.WithMarshalerOption(contentTypeProtobuf, &xProtobufMarshaler{}),
 			gatewayruntime.WithMarshalerOption(gatewayruntime.MIMEWildcard, jsonpb),
 		)
 	}
@@ -89,68 +115,195 @@ func newOtlpReceiver(cfg *Config, logger *zap.Logger) (*otlpReceiver, error) {
 	return r, nil
 }
 
-func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host component.Host) error {
-	r.logger.Info("Starting GRPC server on endpoint " + cfg.NetAddr.Endpoint)
-	var gln net.Listener
-	gln, err := cfg.ToListener()
+// newGRPCServer creates a gRPC server for the given settings with the services
+// of the consumers registered so far.
+func (r *otlpReceiver) newGRPCServer(cfg *configgrpc.GRPCServerSettings) (*grpc.Server, error) {
+	opts, err := cfg.ToServerOption()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	opts = append(opts, grpc.StatsHandler(&connStatsHandler{receiver: r.cfg.Name()}))
+	if r.limiter != nil {
+		opts = append(opts, grpc.ChainUnaryInterceptor(r.limiter.unaryInterceptor))
+	}
+	srv := grpc.NewServer(opts...)
+	if r.traceReceiver != nil {
+		collectortrace.RegisterTraceServiceServer(srv, r.traceReceiver)
+	}
+	if r.metricsReceiver != nil {
+		collectormetrics.RegisterMetricsServiceServer(srv, r.metricsReceiver)
+	}
+	if r.logReceiver != nil {
+		collectorlog.RegisterLogsServiceServer(srv, r.logReceiver)
+	}
+	return srv, nil
+}
+
+// serve runs fn in the background and reports its error to the host, unless the
+// servers were stopped on purpose through stopCh.
+func serve(host component.Host, stopCh chan struct{}, fn func() error) {
 	go func() {
-		if errGrpc := r.serverGRPC.Serve(gln); errGrpc != nil {
-			host.ReportFatalError(errGrpc)
+		if err := fn(); err != nil && err != http.ErrServerClosed {
+			select {
+			case <-stopCh:
+			default:
+				host.ReportFatalError(err)
+			}
 		}
 	}()
-	return nil
 }
 
-func (r *otlpReceiver) startHTTPServer(cfg *confighttp.HTTPServerSettings, host component.Host) error {
-	r.logger.Info("Starting HTTP server on endpoint " + cfg.Endpoint)
-	var hln net.Listener
-	hln, err := r.cfg.HTTP.ToListener()
-	if err != nil {
-		return err
+// attach returns a listener for the current servers on the socket bound to endpoint.
+func (r *otlpReceiver) attach(endpoint string) net.Listener {
+	ln := r.listeners[endpoint].attach()
+	r.attached = append(r.attached, ln)
+	return ln
+}
+
+func (r *otlpReceiver) startGRPCServer(endpoint string, host component.Host) {
+	r.logger.Info("Starting GRPC server on endpoint " + endpoint)
+	gln := r.cfg.GRPC.WrapListener(r.attach(endpoint))
+	srv := r.serverGRPC
+	serve(host, r.stopCh, func() error { return srv.Serve(gln) })
+}
+
+func (r *otlpReceiver) startHTTPServer(tlsCfg *tls.Config, host component.Host) {
+	r.logger.Info("Starting HTTP server on endpoint " + r.cfg.HTTP.Endpoint)
+	hln := r.attach(r.cfg.HTTP.Endpoint)
+	if tlsCfg != nil {
+		hln = tls.NewListener(hln, tlsCfg)
 	}
-	go func() {
-		if errHTTP := r.serverHTTP.Serve(hln); errHTTP != nil {
-			host.ReportFatalError(errHTTP)
-		}
-	}()
-	return nil
+	srv := r.serverHTTP
+	serve(host, r.stopCh, func() error { return srv.Serve(hln) })
 }
 
-func (r *otlpReceiver) startProtocolServers(host component.Host) error {
-	var err error
-	if r.cfg.GRPC != nil {
-		err = r.startGRPCServer(r.cfg.GRPC, host)
+// setDefaultHTTPTimeouts protects the HTTP server against slow clients holding
+// connections open, unless the timeouts were explicitly configured. ReadTimeout
+// is left unset since it would also cut off large or slow uploads.
+func setDefaultHTTPTimeouts(srv *http.Server) {
+	if srv.ReadHeaderTimeout == 0 {
+		srv.ReadHeaderTimeout = defaultHTTPReadHeaderTimeout
+	}
+	if srv.IdleTimeout == 0 {
+		srv.IdleTimeout = defaultHTTPIdleTimeout
+	}
+}
+
+// startSharedServer serves gRPC and HTTP on a single listener. Connections are
+// dispatched by protocol: HTTP/2 connections with a gRPC content-type go to the
+// gRPC server and all the others to the HTTP server.
+func (r *otlpReceiver) startSharedServer(host component.Host) {
+	endpoint := r.cfg.GRPC.NetAddr.Endpoint
+	r.logger.Info("Starting GRPC and HTTP servers on shared endpoint " + endpoint)
+	m := cmux.New(r.attach(endpoint))
+	// Bound protocol sniffing so a silent client cannot hold a connection open
+	// before the HTTP server's own timeouts apply.
+	m.SetReadTimeout(r.serverHTTP.ReadHeaderTimeout)
+	// The gRPC connection settings only apply to the gRPC connections.
+	gln := r.cfg.GRPC.WrapListener(m.MatchWithWriters(cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc")))
+	hln := m.Match(cmux.Any())
+	srvGRPC, srvHTTP := r.serverGRPC, r.serverHTTP
+	serve(host, r.stopCh, func() error { return srvGRPC.Serve(gln) })
+	serve(host, r.stopCh, func() error { return srvHTTP.Serve(hln) })
+	serve(host, r.stopCh, m.Serve)
+}
+
+// bindListeners returns the sockets of the endpoints of cfg. The sockets of the
+// current servers are reused for the endpoints they already serve, the others
+// are bound. Nothing is left bound if an error is returned.
+func (r *otlpReceiver) bindListeners(cfg *Config) (map[string]*boundListener, error) {
+	var addrs []confignet.NetAddr
+	if cfg.GRPC != nil {
+		addrs = append(addrs, cfg.GRPC.NetAddr)
+		if cfg.GRPC.NetAddr.Endpoint == defaultGRPCEndpoint {
+			addrs = append(addrs, confignet.NetAddr{Endpoint: legacyGRPCEndpoint, Transport: cfg.GRPC.NetAddr.Transport})
+		}
+	}
+	if cfg.HTTP != nil {
+		addrs = append(addrs, confignet.NetAddr{Endpoint: cfg.HTTP.Endpoint, Transport: "tcp"})
+	}
+
+	listeners := make(map[string]*boundListener, len(addrs))
+	for _, addr := range addrs {
+		if _, ok := listeners[addr.Endpoint]; ok {
+			continue
+		}
+		if bl, ok := r.listeners[addr.Endpoint]; ok {
+			listeners[addr.Endpoint] = bl
+			continue
+		}
+		ln, err := addr.Listen()
 		if err != nil {
-			return err
+			closeListeners(listeners, r.listeners)
+			return nil, err
 		}
-		if r.cfg.GRPC.NetAddr.Endpoint == defaultGRPCEndpoint {
-			r.logger.Info("Setting up a second GRPC listener on legacy endpoint " + legacyGRPCEndpoint)
+		listeners[addr.Endpoint] = newBoundListener(ln)
+	}
+	return listeners, nil
+}
 
-			// Copy the config.
-			cfgLegacyGRPC := r.cfg.GRPC
-			// And use the legacy endpoint.
-			cfgLegacyGRPC.NetAddr.Endpoint = legacyGRPCEndpoint
-			err = r.startGRPCServer(cfgLegacyGRPC, host)
-			if err != nil {
-				return err
-			}
+// closeListeners closes the sockets of listeners that are not in keep.
+func closeListeners(listeners, keep map[string]*boundListener) {
+	for endpoint, bl := range listeners {
+		if keep[endpoint] != bl {
+			_ = bl.Close()
 		}
 	}
+}
+
+// startProtocolServers binds the endpoints of r.cfg and starts serving them. The
+// receiver is left unchanged if an error is returned.
+func (r *otlpReceiver) startProtocolServers(host component.Host) error {
+	shared := r.cfg.GRPC != nil && r.cfg.HTTP != nil && r.cfg.GRPC.NetAddr.Endpoint == r.cfg.HTTP.Endpoint
+	// Protocol sniffing needs to see the plaintext of the connection.
+	if shared && (r.cfg.GRPC.TLSSetting != nil || r.cfg.HTTP.TLSSetting != nil) {
+		return errSharedEndpointTLS
+	}
+
+	var serverHTTP *http.Server
+	var httpTLSCfg *tls.Config
 	if r.cfg.HTTP != nil {
-		r.serverHTTP = r.cfg.HTTP.ToServer(
-			r.gatewayMux,
+		if r.cfg.HTTP.TLSSetting != nil {
+			var err error
+			if httpTLSCfg, err = r.cfg.HTTP.TLSSetting.LoadTLSConfig(); err != nil {
+				return err
+			}
+		}
+		serverHTTP = r.cfg.HTTP.ToServer(
+			recordBodyTooLarge(normalizeContentType(r.gatewayMux), r.cfg.HTTP.MaxRequestBodySize),
 			confighttp.WithErrorHandler(errorHandler),
 		)
-		err = r.startHTTPServer(r.cfg.HTTP, host)
-		if err != nil {
-			return err
-		}
+		setDefaultHTTPTimeouts(serverHTTP)
+		serverHTTP.Handler = recordRequests(r.cfg.Name(), r.limiter.httpHandler(
+			middleware.HTTPContentCompressor(serverHTTP.Handler)))
 	}
 
-	return err
+	listeners, err := r.bindListeners(r.cfg)
+	if err != nil {
+		return err
+	}
+	r.serverHTTP = serverHTTP
+	r.listeners = listeners
+	r.attached = nil
+	r.stopCh = make(chan struct{})
+
+	if shared {
+		// Both protocols are configured on the same endpoint, share the listener
+		// instead of failing to bind it twice.
+		r.startSharedServer(host)
+	} else {
+		if r.cfg.GRPC != nil {
+			r.startGRPCServer(r.cfg.GRPC.NetAddr.Endpoint, host)
+		}
+		if r.cfg.HTTP != nil {
+			r.startHTTPServer(httpTLSCfg, host)
+		}
+	}
+	if r.cfg.GRPC != nil && r.cfg.GRPC.NetAddr.Endpoint == defaultGRPCEndpoint {
+		r.logger.Info("Setting up a second GRPC listener on legacy endpoint " + legacyGRPCEndpoint)
+		r.startGRPCServer(legacyGRPCEndpoint, host)
+	}
+	return nil
 }
 
 // Start runs the trace receiver on the gRPC server. Currently
@@ -162,15 +315,99 @@ func (r *otlpReceiver) Start(_ context.Context, host component.Host) error {
 
 	var err error
 	r.startServerOnce.Do(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.host = host
 		err = r.startProtocolServers(host)
 	})
 	return err
 }
 
+// Reload rebinds the receiver to the endpoints and TLS settings of cfg while keeping
+// the registered consumers attached. The new servers are started first, on the
+// sockets already bound for the endpoints that do not change. The old servers then
+// stop accepting connections and drain their in-flight requests in the background,
+// connections still open when ctx is done are closed. If the new servers can not be
+// started the old ones keep running and the error is returned. Enabling or disabling
+// a protocol or changing MaxConcurrentRequests requires a restart.
+func (r *otlpReceiver) Reload(ctx context.Context, cfg *Config) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.host == nil {
+		return errReloadNotRunning
+	}
+	if (cfg.GRPC == nil) != (r.cfg.GRPC == nil) || (cfg.HTTP == nil) != (r.cfg.HTTP == nil) {
+		return errReloadProtocols
+	}
+
+	var serverGRPC *grpc.Server
+	if cfg.GRPC != nil {
+		var err error
+		if serverGRPC, err = r.newGRPCServer(cfg.GRPC); err != nil {
+			return err
+		}
+	}
+
+	oldCfg, oldGRPC, oldHTTP := r.cfg, r.serverGRPC, r.serverHTTP
+	oldListeners, oldAttached, oldStopCh := r.listeners, r.attached, r.stopCh
+	r.cfg, r.serverGRPC = cfg, serverGRPC
+	if err := r.startProtocolServers(r.host); err != nil {
+		r.cfg, r.serverGRPC = oldCfg, oldGRPC
+		return err
+	}
+
+	close(oldStopCh)
+	for _, ln := range oldAttached {
+		_ = ln.Close()
+	}
+	closeListeners(oldListeners, r.listeners)
+	drainCtx, cancel := context.WithCancel(ctx)
+	r.cancelDrains = append(r.cancelDrains, cancel)
+	go func() {
+		defer cancel()
+		r.drainServers(drainCtx, oldGRPC, oldHTTP)
+	}()
+	return nil
+}
+
+// drainServers waits for the in-flight requests of servers that stopped accepting
+// connections to complete. Connections still open when ctx is done are closed.
+func (r *otlpReceiver) drainServers(ctx context.Context, serverGRPC *grpc.Server, serverHTTP *http.Server) {
+	if serverHTTP != nil {
+		if err := serverHTTP.Shutdown(ctx); err != nil {
+			r.logger.Warn("Closing HTTP connections that did not drain in time", zap.Error(err))
+			_ = serverHTTP.Close()
+		}
+	}
+	if serverGRPC != nil {
+		stopped := make(chan struct{})
+		go func() {
+			serverGRPC.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			r.logger.Warn("Closing gRPC connections that did not drain in time", zap.Error(ctx.Err()))
+			serverGRPC.Stop()
+		}
+	}
+}
+
 // Shutdown is a method to turn off receiving.
 func (r *otlpReceiver) Shutdown(context.Context) error {
 	var err error
 	r.stopOnce.Do(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.host = nil
+		if r.stopCh != nil {
+			close(r.stopCh)
+		}
+		for _, cancel := range r.cancelDrains {
+			cancel()
+		}
+		closeListeners(r.listeners, nil)
 		err = nil
 
 		if r.serverHTTP != nil {
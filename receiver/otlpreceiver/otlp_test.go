@@ -518,6 +518,695 @@ func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 	}
 }
 
+func TestHTTPGzipAcrossSignals(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.GRPC = nil
+	tSink := new(consumertest.TracesSink)
+	mSink := new(consumertest.MetricsSink)
+	lSink := new(consumertest.LogsSink)
+	ocr := newReceiver(t, factory, cfg, tSink, mSink)
+	_, err := factory.CreateLogsReceiver(context.Background(), component.ReceiverCreateParams{}, cfg, lSink)
+	require.NoError(t, err)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	traces, err := testdata.GenerateTraceDataOneSpan().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	metrics, err := testdata.GenerateMetricsOneMetric().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	logs, err := testdata.GenerateLogDataOneLog().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	bodies := map[string][]byte{
+		"/v1/traces":  traces,
+		"/v1/metrics": metrics,
+		"/v1/logs":    logs,
+	}
+
+	// The gzip readers released by a signal are reused by the others.
+	const rounds = 3
+	for i := 0; i < rounds; i++ {
+		for path, body := range bodies {
+			compressed, err := compressGzip(body)
+			require.NoError(t, err)
+			req, err := http.NewRequest("POST", fmt.Sprintf("http://%s%s", addr, path), compressed)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/x-protobuf")
+			req.Header.Set("Content-Encoding", "gzip")
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusOK, resp.StatusCode, path)
+		}
+	}
+
+	require.Len(t, tSink.AllTraces(), rounds)
+	assert.Equal(t, testdata.GenerateTraceDataOneSpan(), tSink.AllTraces()[0])
+	require.Len(t, mSink.AllMetrics(), rounds)
+	assert.Equal(t, testdata.GenerateMetricsOneMetric(), mSink.AllMetrics()[0])
+	require.Len(t, lSink.AllLogs(), rounds)
+	assert.Equal(t, testdata.GenerateLogDataOneLog(), lSink.AllLogs()[0])
+}
+
+func TestHTTPMaxRequestBodySize(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.MaxRequestBodySize = int64(len(traceJSON))
+	cfg.GRPC = nil
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	post := func(contentType string, body []byte) (int, []byte) {
+		resp, err := http.Post(fmt.Sprintf("http://%s/v1/traces", addr), contentType, bytes.NewReader(body))
+		require.NoError(t, err)
+		respBody, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode, respBody
+	}
+
+	code, _ := post("application/json", traceJSON)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, sink.AllTraces(), 1)
+
+	code, body := post("application/x-protobuf", make([]byte, len(traceJSON)+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	var st spb.Status
+	require.NoError(t, proto.Unmarshal(body, &st))
+	assert.Equal(t, int32(codes.InvalidArgument), st.Code)
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestHTTPMaxRequestBodySizeChunked(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.MaxRequestBodySize = int64(len(traceJSON))
+	cfg.GRPC = nil
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	// A body of unknown length, not a *bytes.Reader, is sent chunked without a Content-Length.
+	post := func(contentType string, body []byte) (int, []byte) {
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/v1/traces", addr), ioutil.NopCloser(bytes.NewReader(body)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		respBody, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode, respBody
+	}
+
+	code, _ := post("application/json", traceJSON)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, sink.AllTraces(), 1)
+
+	code, body := post("application/x-protobuf", make([]byte, len(traceJSON)+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	var st spb.Status
+	require.NoError(t, proto.Unmarshal(body, &st))
+	assert.Equal(t, int32(codes.InvalidArgument), st.Code)
+
+	code, body = post("application/json", append(traceJSON, ' '))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	assert.Contains(t, string(body), "larger than the limit")
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestHTTPGzipResponse(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	ocr := newHTTPReceiver(t, addr, new(consumertest.TracesSink), new(consumertest.MetricsSink))
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	// Setting Accept-Encoding explicitly disables the transparent decompression of the client.
+	post := func(acceptEncoding string) *http.Response {
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/v1/traces", addr), bytes.NewBuffer(traceJSON))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := post("")
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	assert.NoError(t, json.Unmarshal(body, &collectortrace.ExportTraceServiceResponse{}))
+
+	resp = post("gzip")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+	gr, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	body, err = ioutil.ReadAll(gr)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.NoError(t, json.Unmarshal(body, &collectortrace.ExportTraceServiceResponse{}))
+}
+
+func TestHTTPResponseEncodingMatchesRequest(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.GRPC = nil
+	ocr := newReceiver(t, factory, cfg, new(consumertest.TracesSink), new(consumertest.MetricsSink))
+	_, err := factory.CreateLogsReceiver(context.Background(), component.ReceiverCreateParams{}, cfg, new(consumertest.LogsSink))
+	require.NoError(t, err)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	defer ocr.Shutdown(context.Background())
+
+	// Wait for the servers to start
+	<-time.After(10 * time.Millisecond)
+
+	tests := []struct {
+		name        string
+		contentType string
+		encoding    string
+		body        []byte
+		status      int
+		respType    string
+	}{
+		{
+			name:        "JSON",
+			contentType: "application/json",
+			body:        []byte(`{}`),
+			status:      200,
+			respType:    "application/json",
+		},
+		{
+			name:        "JSONWithCharset",
+			contentType: "application/json; charset=utf-8",
+			body:        []byte(`{}`),
+			status:      200,
+			respType:    "application/json",
+		},
+		{
+			name:        "Protobuf",
+			contentType: "application/x-protobuf",
+			body:        []byte{},
+			status:      200,
+			respType:    "application/x-protobuf",
+		},
+		{
+			name:        "JSONWithCharsetError",
+			contentType: "application/json; charset=utf-8",
+			encoding:    "gzip",
+			body:        []byte(`{}`),
+			status:      400,
+			respType:    "application/json",
+		},
+	}
+
+	for _, path := range []string{"/v1/traces", "/v1/metrics", "/v1/logs"} {
+		for _, test := range tests {
+			t.Run(test.name+path, func(t *testing.T) {
+				req, err := http.NewRequest("POST", fmt.Sprintf("http://%s%s", addr, path), bytes.NewBuffer(test.body))
+				require.NoError(t, err, "Error creating POST request: %v", err)
+				req.Header.Set("Content-Type", test.contentType)
+				req.Header.Set("Content-Encoding", test.encoding)
+
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err, "Error posting to grpc-gateway server: %v", err)
+				respBytes, err := ioutil.ReadAll(resp.Body)
+				require.NoError(t, err, "Error reading response from grpc-gateway")
+				require.NoError(t, resp.Body.Close(), "Error closing response body")
+
+				require.Equal(t, test.status, resp.StatusCode, "Unexpected return status")
+				require.Equal(t, test.respType, resp.Header.Get("Content-Type"), "Unexpected response Content-Type")
+				if test.status != 200 {
+					errStatus := &spb.Status{}
+					if test.respType == "application/json" {
+						require.NoError(t, json.Unmarshal(respBytes, errStatus))
+					} else {
+						require.NoError(t, proto.Unmarshal(respBytes, errStatus))
+					}
+					assert.Equal(t, int32(codes.InvalidArgument), errStatus.Code)
+					return
+				}
+				if test.respType == "application/json" {
+					var respJSON map[string]interface{}
+					require.NoError(t, json.Unmarshal(respBytes, &respJSON))
+					assert.Len(t, respJSON, 0)
+				} else {
+					assert.Len(t, respBytes, 0)
+				}
+			})
+		}
+	}
+}
+
+func TestGRPCAndHTTPSharedEndpoint(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.HTTP.Endpoint = addr
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+
+	cc, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+	_, err = collectortrace.NewTraceServiceClient(cc).Export(context.Background(), &collectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{&resourceSpansOtlp},
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/v1/traces", addr), bytes.NewBuffer(traceJSON))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, 200, resp.StatusCode)
+
+	require.Len(t, sink.AllTraces(), 2)
+
+	require.NoError(t, ocr.Shutdown(context.Background()))
+	_, err = net.DialTimeout("tcp", addr, time.Second)
+	assert.Error(t, err)
+}
+
+func TestGRPCAndHTTPSharedEndpointMaxConnections(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.GRPC.MaxConnections = 1
+	cfg.HTTP.Endpoint = addr
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	// Hold the only gRPC connection open.
+	cc, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+	_, err = collectortrace.NewTraceServiceClient(cc).Export(context.Background(), &collectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{&resourceSpansOtlp},
+	})
+	require.NoError(t, err)
+
+	// HTTP connections are not counted against the gRPC limit.
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/v1/traces", addr), bytes.NewBuffer(traceJSON))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Close = true
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, 200, resp.StatusCode)
+	}
+	assert.Len(t, sink.AllTraces(), 3)
+}
+
+func TestReloadSharedEndpoint(t *testing.T) {
+	oldAddr := testutil.GetAvailableLocalAddress(t)
+	newAddr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.GRPC.NetAddr.Endpoint = oldAddr
+	cfg.HTTP.Endpoint = oldAddr
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	newCfg := *ocr.cfg
+	newGRPC := *ocr.cfg.GRPC
+	newGRPC.NetAddr.Endpoint = newAddr
+	newHTTP := *ocr.cfg.HTTP
+	newHTTP.Endpoint = newAddr
+	newCfg.GRPC = &newGRPC
+	newCfg.HTTP = &newHTTP
+	require.NoError(t, ocr.Reload(context.Background(), &newCfg))
+
+	cc, err := grpc.Dial(newAddr, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+	_, err = collectortrace.NewTraceServiceClient(cc).Export(context.Background(), &collectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{&resourceSpansOtlp},
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Post(fmt.Sprintf("http://%s/v1/traces", newAddr), "application/json", bytes.NewBuffer(traceJSON))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Len(t, sink.AllTraces(), 2)
+
+	_, err = net.DialTimeout("tcp", oldAddr, time.Second)
+	assert.Error(t, err)
+}
+
+func TestLegacyGRPCEndpointKeepsConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP = nil
+	ocr := newReceiver(t, factory, cfg, new(consumertest.TracesSink), nil)
+	if err := ocr.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Skipf("default gRPC endpoints are not available: %v", err)
+	}
+	defer ocr.Shutdown(context.Background())
+
+	// Serving the legacy endpoint must not change the configured one, which
+	// Reload copies.
+	assert.Equal(t, defaultGRPCEndpoint, ocr.cfg.GRPC.NetAddr.Endpoint)
+	newCfg := *ocr.cfg
+	newGRPC := *ocr.cfg.GRPC
+	newCfg.GRPC = &newGRPC
+	require.NoError(t, ocr.Reload(context.Background(), &newCfg))
+	assert.Equal(t, defaultGRPCEndpoint, ocr.cfg.GRPC.NetAddr.Endpoint)
+}
+
+func TestGRPCAndHTTPSharedEndpointWithTLS(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.TLSSetting = &configtls.TLSServerSetting{}
+	r := newReceiver(t, factory, cfg, new(consumertest.TracesSink), nil)
+	assert.Equal(t, errSharedEndpointTLS, r.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestHTTPDefaultTimeouts(t *testing.T) {
+	ocr := newHTTPReceiver(t, testutil.GetAvailableLocalAddress(t), new(consumertest.TracesSink), nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	assert.Equal(t, defaultHTTPReadHeaderTimeout, ocr.serverHTTP.ReadHeaderTimeout)
+	assert.Zero(t, ocr.serverHTTP.ReadTimeout)
+	assert.Equal(t, defaultHTTPIdleTimeout, ocr.serverHTTP.IdleTimeout)
+}
+
+func TestHTTPSlowClientIsDisconnected(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.ReadHeaderTimeout = 100 * time.Millisecond
+	ocr := newReceiver(t, factory, cfg, new(consumertest.TracesSink), nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Send an incomplete request header and never finish it.
+	_, err = conn.Write([]byte("POST /v1/traces HTTP/1.1\r\nHost: localhost\r\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = ioutil.ReadAll(conn)
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		assert.False(t, netErr.Timeout(), "server did not close the connection of a slow client")
+	}
+}
+
+func TestReloadToNewEndpoint(t *testing.T) {
+	oldAddr := testutil.GetAvailableLocalAddress(t)
+	newAddr := testutil.GetAvailableLocalAddress(t)
+	sink := newGatedTracesSink()
+	ocr := newHTTPReceiver(t, oldAddr, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	postTraces := func(addr string) (int, error) {
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/v1/traces", addr), bytes.NewBuffer(traceJSON))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode, resp.Body.Close()
+	}
+
+	// Keep a request in flight on the old endpoint.
+	oldStatus := make(chan int, 1)
+	go func() {
+		status, err := postTraces(oldAddr)
+		assert.NoError(t, err)
+		oldStatus <- status
+	}()
+	<-sink.started
+
+	newCfg := *ocr.cfg
+	newHTTP := *ocr.cfg.HTTP
+	newHTTP.Endpoint = newAddr
+	newCfg.HTTP = &newHTTP
+	require.NoError(t, ocr.Reload(context.Background(), &newCfg))
+
+	// The new endpoint accepts requests while the old request is still held.
+	newStatus := make(chan int, 1)
+	go func() {
+		status, err := postTraces(newAddr)
+		assert.NoError(t, err)
+		newStatus <- status
+	}()
+	<-sink.started
+	select {
+	case <-oldStatus:
+		t.Fatal("the in-flight request on the old endpoint completed before it was released")
+	default:
+	}
+	_, err := postTraces(oldAddr)
+	assert.Error(t, err)
+
+	close(sink.release)
+	assert.Equal(t, 200, <-oldStatus)
+	assert.Equal(t, 200, <-newStatus)
+	assert.Len(t, sink.AllTraces(), 2)
+}
+
+func TestReloadKeepsServingOnBindError(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	usedAddr := testutil.GetAvailableLocalAddress(t)
+	ln, err := net.Listen("tcp", usedAddr)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	sink := new(consumertest.TracesSink)
+	ocr := newHTTPReceiver(t, addr, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	newCfg := *ocr.cfg
+	newHTTP := *ocr.cfg.HTTP
+	newHTTP.Endpoint = usedAddr
+	newCfg.HTTP = &newHTTP
+	assert.Error(t, ocr.Reload(context.Background(), &newCfg))
+	assert.Equal(t, addr, ocr.cfg.HTTP.Endpoint)
+
+	resp, err := http.Post(fmt.Sprintf("http://%s/v1/traces", addr), "application/json", bytes.NewBuffer(traceJSON))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestReloadSameEndpoint(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := new(consumertest.TracesSink)
+	ocr := newHTTPReceiver(t, addr, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	newCfg := *ocr.cfg
+	require.NoError(t, ocr.Reload(context.Background(), &newCfg))
+
+	resp, err := http.Post(fmt.Sprintf("http://%s/v1/traces", addr), "application/json", bytes.NewBuffer(traceJSON))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestReloadGRPCToNewEndpoint(t *testing.T) {
+	oldAddr := testutil.GetAvailableLocalAddress(t)
+	newAddr := testutil.GetAvailableLocalAddress(t)
+	sink := new(consumertest.TracesSink)
+	ocr := newGRPCReceiver(t, otlpReceiverName, oldAddr, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	newCfg := *ocr.cfg
+	newGRPC := *ocr.cfg.GRPC
+	newGRPC.NetAddr.Endpoint = newAddr
+	newCfg.GRPC = &newGRPC
+	require.NoError(t, ocr.Reload(context.Background(), &newCfg))
+
+	cc, err := grpc.Dial(newAddr, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+	_, err = collectortrace.NewTraceServiceClient(cc).Export(context.Background(), &collectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{&resourceSpansOtlp},
+	})
+	require.NoError(t, err)
+	assert.Len(t, sink.AllTraces(), 1)
+
+	_, err = net.DialTimeout("tcp", oldAddr, time.Second)
+	assert.Error(t, err)
+}
+
+func TestReloadErrors(t *testing.T) {
+	ocr := newHTTPReceiver(t, testutil.GetAvailableLocalAddress(t), new(consumertest.TracesSink), nil)
+	newCfg := *ocr.cfg
+	assert.Equal(t, errReloadNotRunning, ocr.Reload(context.Background(), &newCfg))
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	newCfg.GRPC = &configgrpc.GRPCServerSettings{
+		NetAddr: confignet.NetAddr{Endpoint: testutil.GetAvailableLocalAddress(t), Transport: "tcp"},
+	}
+	assert.Equal(t, errReloadProtocols, ocr.Reload(context.Background(), &newCfg))
+
+	require.NoError(t, ocr.Shutdown(context.Background()))
+	newCfg.GRPC = nil
+	assert.Equal(t, errReloadNotRunning, ocr.Reload(context.Background(), &newCfg))
+}
+
+func TestHTTPRequestsByStatus(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	ocr := newHTTPReceiver(t, addr, new(consumertest.TracesSink), new(consumertest.MetricsSink))
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	post := func(path, contentType string, body []byte) int {
+		resp, err := http.Post(fmt.Sprintf("http://%s%s", addr, path), contentType, bytes.NewReader(body))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, post("/v1/traces", "application/json", []byte(`{}`)))
+	assert.Equal(t, http.StatusBadRequest, post("/v1/traces", "text/plain", []byte("not otlp")))
+	assert.Equal(t, http.StatusBadRequest, post("/v1/metrics", "text/plain", []byte("not otlp")))
+
+	obsreporttest.CheckReceiverRequestsViews(t, otlpReceiverName, httpTransport, "traces", "2xx", 1)
+	obsreporttest.CheckReceiverRequestsViews(t, otlpReceiverName, httpTransport, "traces", "4xx", 1)
+	obsreporttest.CheckReceiverRequestsViews(t, otlpReceiverName, httpTransport, "metrics", "4xx", 1)
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.MaxConcurrentRequests = 2
+	sink := newGatedTracesSink()
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	cc, err := grpc.Dial(grpcAddr, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+	exportGRPC := func() error {
+		_, err := collectortrace.NewTraceServiceClient(cc).Export(context.Background(), &collectortrace.ExportTraceServiceRequest{
+			ResourceSpans: []*otlptrace.ResourceSpans{&resourceSpansOtlp},
+		})
+		return err
+	}
+	postHTTP := func() int {
+		resp, err := http.Post(fmt.Sprintf("http://%s/v1/traces", httpAddr), "application/json", bytes.NewBuffer(traceJSON))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	// Fill the limit with one request of each protocol.
+	grpcDone := make(chan error, 1)
+	go func() { grpcDone <- exportGRPC() }()
+	<-sink.started
+	httpDone := make(chan int, 1)
+	go func() { httpDone <- postHTTP() }()
+	<-sink.started
+
+	err = exportGRPC()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, http.StatusServiceUnavailable, postHTTP())
+
+	close(sink.release)
+	assert.NoError(t, <-grpcDone)
+	assert.Equal(t, http.StatusOK, <-httpDone)
+	assert.NoError(t, exportGRPC())
+	assert.Equal(t, http.StatusOK, postHTTP())
+	assert.Len(t, sink.AllTraces(), 4)
+}
+
+func TestGRPCMaxConnections(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.GRPC.MaxConnections = 1
+	cfg.HTTP = nil
+	sink := new(consumertest.TracesSink)
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	defer ocr.Shutdown(context.Background())
+
+	export := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		cc, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+		require.NoError(t, err)
+		t.Cleanup(func() { cc.Close() })
+		_, err = collectortrace.NewTraceServiceClient(cc).Export(ctx, &collectortrace.ExportTraceServiceRequest{
+			ResourceSpans: []*otlptrace.ResourceSpans{&resourceSpansOtlp},
+		})
+		return err
+	}
+	require.NoError(t, export())
+	assert.Error(t, export())
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
 func TestGRPCNewPortAlreadyUsed(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	ln, err := net.Listen("tcp", addr)
@@ -762,6 +1451,30 @@ func newReceiver(t *testing.T, factory component.ReceiverFactory, cfg *Config, t
 	return r
 }
 
+// gatedTracesSink holds every request until release is closed and signals
+// started when a request arrives.
+type gatedTracesSink struct {
+	consumertest.TracesSink
+	started chan struct{}
+	release chan struct{}
+}
+
+func newGatedTracesSink() *gatedTracesSink {
+	return &gatedTracesSink{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+}
+
+func (s *gatedTracesSink) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	select {
+	case s.started <- struct{}{}:
+	default:
+	}
+	<-s.release
+	return s.TracesSink.ConsumeTraces(ctx, td)
+}
+
 func compressGzip(body []byte) (*bytes.Buffer, error) {
 	var buf bytes.Buffer
 
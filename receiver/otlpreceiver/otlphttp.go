@@ -16,6 +16,10 @@ package otlpreceiver
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
 
 	"github.com/gogo/protobuf/jsonpb"
@@ -23,8 +27,29 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+
+	httpTransport = "http"
+
+	// errBodyTooLargeMsg is the error of the reads past the limit of http.MaxBytesReader.
+	errBodyTooLargeMsg = "http: request body too large"
 )
 
+// httpPathSignals maps the OTLP HTTP paths to the signal they receive.
+var httpPathSignals = map[string]configmodels.DataType{
+	"/v1/trace":   configmodels.TracesDataType,
+	"/v1/traces":  configmodels.TracesDataType,
+	"/v1/metrics": configmodels.MetricsDataType,
+	"/v1/logs":    configmodels.LogsDataType,
+}
+
 // xProtobufMarshaler is a Marshaler which wraps runtime.ProtoMarshaller
 // and sets ContentType to application/x-protobuf
 type xProtobufMarshaler struct {
@@ -33,13 +58,107 @@ type xProtobufMarshaler struct {
 
 // ContentType always returns "application/x-protobuf".
 func (*xProtobufMarshaler) ContentType() string {
-	return "application/x-protobuf"
+	return contentTypeProtobuf
+}
+
+// requestMediaType returns the media type of the request Content-Type header
+// without any parameters such as charset.
+func requestMediaType(r *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return r.Header.Get("Content-Type")
+	}
+	return mediaType
+}
+
+// normalizeContentType strips the parameters from the request Content-Type header so
+// that the gateway selects the same marshaler for the request and the response as
+// errorHandler does, e.g. for "application/json; charset=utf-8".
+func normalizeContentType(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "" {
+			r.Header.Set("Content-Type", requestMediaType(r))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// limitedBody records whether reading the request body failed because it was
+// larger than max_request_body_size, e.g. for a chunked body without a Content-Length.
+type limitedBody struct {
+	io.ReadCloser
+	limit    int64
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err.Error() == errBodyTooLargeMsg {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// recordBodyTooLarge wraps the request bodies so that protoErrorHandler reports the
+// ones exceeding maxBytes. Nothing is wrapped if maxBytes is not positive.
+func recordBodyTooLarge(h http.Handler, maxBytes int64) http.Handler {
+	if maxBytes <= 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = &limitedBody{ReadCloser: r.Body, limit: maxBytes}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// protoErrorHandler responds with 413 Request Entity Too Large when the request body
+// exceeded max_request_body_size while being read, like for the requests rejected
+// from their Content-Length. The other errors are handled by the gateway default.
+func protoErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if b, ok := r.Body.(*limitedBody); ok && b.exceeded {
+		errorHandler(w, r, fmt.Sprintf("request body is larger than the limit of %d bytes", b.limit), http.StatusRequestEntityTooLarge)
+		return
+	}
+	runtime.DefaultHTTPProtoErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// statusClass returns the class of an HTTP response status, e.g. "4xx" for 429.
+func statusClass(statusCode int) string {
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
+
+// statusRecorder is an http.ResponseWriter that remembers the response status.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusRecorder) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// recordRequests records the response status class of the requests sent to
+// the OTLP paths, including the ones rejected before reaching the gateway.
+func recordRequests(receiver string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signal, ok := httpPathSignals[r.URL.Path]
+		if !ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+		rec := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		h.ServeHTTP(rec, r)
+		ctx := obsreport.ReceiverContext(r.Context(), receiver, httpTransport)
+		obsreport.ReceiverRequestCompleted(ctx, signal, statusClass(rec.statusCode))
+	})
 }
 
 var jsonMarshaller = &jsonpb.Marshaler{}
 
 // errorHandler encodes the HTTP error message inside a rpc.Status message as required
-// by the OTLP protocol.
+// by the OTLP protocol. Like the gateway, the message is encoded as protobuf for
+// protobuf requests and as JSON for every other content type.
 func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusCode int) {
 	var (
 		msg []byte
@@ -48,21 +167,25 @@ func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusC
 	)
 	// Pre-computed status with code=Internal to be used in case of a marshaling error.
 	fallbackMsg := []byte(`{"code": 13, "message": "failed to marshal error message"}`)
-	fallbackContentType := "application/json"
+	fallbackContentType := contentTypeJSON
 
-	if statusCode == http.StatusBadRequest {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
 		s = status.New(codes.InvalidArgument, errMsg)
-	} else {
+	case http.StatusServiceUnavailable:
+		s = status.New(codes.Unavailable, errMsg)
+	default:
 		s = status.New(codes.Internal, errMsg)
 	}
 
-	contentType := r.Header.Get("Content-Type")
-	if contentType == "application/json" {
+	contentType := contentTypeJSON
+	if requestMediaType(r) == contentTypeProtobuf {
+		contentType = contentTypeProtobuf
+		msg, err = proto.Marshal(s.Proto())
+	} else {
 		buf := new(bytes.Buffer)
 		err = jsonMarshaller.Marshal(buf, s.Proto())
 		msg = buf.Bytes()
-	} else {
-		msg, err = proto.Marshal(s.Proto())
 	}
 	if err != nil {
 		msg = fallbackMsg
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errTooManyRequestsMsg = "too many concurrent requests"
+
+// requestLimiter bounds the number of requests handled concurrently by the
+// gRPC and HTTP servers of the receiver. Requests above the limit are rejected
+// instead of queued. A nil requestLimiter does not limit anything.
+type requestLimiter struct {
+	sem chan struct{}
+}
+
+// newRequestLimiter returns a requestLimiter allowing max concurrent requests,
+// or nil if max is not positive.
+func newRequestLimiter(max int) *requestLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &requestLimiter{sem: make(chan struct{}, max)}
+}
+
+// acquire reserves a slot for a request, it returns false if all the slots are in use.
+func (l *requestLimiter) acquire() bool {
+	if l == nil {
+		return true
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees the slot reserved by a successful acquire.
+func (l *requestLimiter) release() {
+	if l != nil {
+		<-l.sem
+	}
+}
+
+// unaryInterceptor rejects the gRPC requests above the limit with RESOURCE_EXHAUSTED.
+func (l *requestLimiter) unaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !l.acquire() {
+		return nil, status.Error(codes.ResourceExhausted, errTooManyRequestsMsg)
+	}
+	defer l.release()
+	return handler(ctx, req)
+}
+
+// httpHandler rejects the HTTP requests above the limit with 503 Service Unavailable.
+func (l *requestLimiter) httpHandler(h http.Handler) http.Handler {
+	if l == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.acquire() {
+			errorHandler(w, r, errTooManyRequestsMsg, http.StatusServiceUnavailable)
+			return
+		}
+		defer l.release()
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"context"
+	"path"
+
+	gatewayruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const grpcTransport = "grpc"
+
+// grpcServiceSignals maps the OTLP gRPC services to the signal they receive.
+var grpcServiceSignals = map[string]configmodels.DataType{
+	"/opentelemetry.proto.collector.trace.v1.TraceService":     configmodels.TracesDataType,
+	"/opentelemetry.proto.collector.metrics.v1.MetricsService": configmodels.MetricsDataType,
+	"/opentelemetry.proto.collector.logs.v1.LogsService":       configmodels.LogsDataType,
+}
+
+// rpcSignalKey is the context key of the signal received by an RPC.
+type rpcSignalKey struct{}
+
+// connStatsHandler is a gRPC stats.Handler that records the number of open
+// connections to the receiver and the status of the requests it handles.
+type connStatsHandler struct {
+	receiver string
+}
+
+var _ stats.Handler = (*connStatsHandler)(nil)
+
+// TagRPC implements stats.Handler. The returned context is passed to
+// HandleRPC for every event of the RPC.
+func (h *connStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if signal, ok := grpcServiceSignals[path.Dir(info.FullMethodName)]; ok {
+		return context.WithValue(ctx, rpcSignalKey{}, signal)
+	}
+	return ctx
+}
+
+// HandleRPC implements stats.Handler.
+func (h *connStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	end, ok := s.(*stats.End)
+	if !ok {
+		return
+	}
+	signal, ok := ctx.Value(rpcSignalKey{}).(configmodels.DataType)
+	if !ok {
+		return
+	}
+	code := status.Code(end.Error)
+	obsreport.ReceiverRequestCompleted(ctx, signal, statusClass(gatewayruntime.HTTPStatusFromCode(code)))
+}
+
+// TagConn implements stats.Handler. The returned context is passed to
+// HandleConn for every event of the connection.
+func (h *connStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return obsreport.ReceiverContext(ctx, h.receiver, grpcTransport)
+}
+
+// HandleConn implements stats.Handler.
+func (h *connStatsHandler) HandleConn(ctx context.Context, s stats.ConnStats) {
+	switch s.(type) {
+	case *stats.ConnBegin:
+		obsreport.ReceiverConnectionOpened(ctx)
+	case *stats.ConnEnd:
+		obsreport.ReceiverConnectionClosed(ctx)
+	}
+}
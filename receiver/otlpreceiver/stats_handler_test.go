@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+func TestConnStatsHandler(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	h := &connStatsHandler{receiver: otlpReceiverName}
+	ctx1 := h.TagConn(context.Background(), &stats.ConnTagInfo{})
+	ctx2 := h.TagConn(context.Background(), &stats.ConnTagInfo{})
+
+	h.HandleConn(ctx1, &stats.ConnBegin{})
+	h.HandleConn(ctx2, &stats.ConnBegin{})
+	obsreporttest.CheckReceiverConnectionsViews(t, otlpReceiverName, grpcTransport, 2)
+
+	h.HandleConn(ctx1, &stats.ConnEnd{})
+	obsreporttest.CheckReceiverConnectionsViews(t, otlpReceiverName, grpcTransport, 1)
+	h.HandleConn(ctx2, &stats.ConnEnd{})
+	obsreporttest.CheckReceiverConnectionsViews(t, otlpReceiverName, grpcTransport, 0)
+}
+
+func TestConnStatsHandlerRequests(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	h := &connStatsHandler{receiver: otlpReceiverName}
+	connCtx := h.TagConn(context.Background(), &stats.ConnTagInfo{})
+	traceCtx := h.TagRPC(connCtx, &stats.RPCTagInfo{FullMethodName: "/opentelemetry.proto.collector.trace.v1.TraceService/Export"})
+	logsCtx := h.TagRPC(connCtx, &stats.RPCTagInfo{FullMethodName: "/opentelemetry.proto.collector.logs.v1.LogsService/Export"})
+	otherCtx := h.TagRPC(connCtx, &stats.RPCTagInfo{FullMethodName: "/grpc.health.v1.Health/Check"})
+
+	h.HandleRPC(traceCtx, &stats.Begin{})
+	h.HandleRPC(traceCtx, &stats.End{})
+	h.HandleRPC(traceCtx, &stats.End{Error: status.Error(codes.ResourceExhausted, "too many requests")})
+	h.HandleRPC(traceCtx, &stats.End{Error: status.Error(codes.InvalidArgument, "bad request")})
+	h.HandleRPC(logsCtx, &stats.End{Error: status.Error(codes.Unavailable, "unavailable")})
+	h.HandleRPC(otherCtx, &stats.End{})
+
+	obsreporttest.CheckReceiverRequestsViews(t, otlpReceiverName, grpcTransport, "traces", "2xx", 1)
+	obsreporttest.CheckReceiverRequestsViews(t, otlpReceiverName, grpcTransport, "traces", "4xx", 2)
+	obsreporttest.CheckReceiverRequestsViews(t, otlpReceiverName, grpcTransport, "logs", "5xx", 1)
+}
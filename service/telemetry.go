@@ -67,6 +67,9 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	views = append(views, processor.MetricViews()...)
 	views = append(views, queuedprocessor.MetricViews()...)
 	views = append(views, batchprocessor.MetricViews()...)
+	if telemetry.GetLegacyBatchMetrics() {
+		views = append(views, batchprocessor.LegacyMetricViews()...)
+	}
 	views = append(views, kafkareceiver.MetricViews()...)
 	views = append(views, processMetricsViews.Views()...)
 	views = append(views, fluentobserv.MetricViews()...)